@@ -0,0 +1,77 @@
+package neteasetest
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/forgoer/openssl"
+)
+
+var (
+	iv        = []byte("0102030405060708")
+	presetKey = []byte("0CoJUm6Qyw8W8jud")
+)
+
+// decryptWeapi 还原 util.Weapi 与 util.ApiParamsEncode 加密的参数，
+// linuxapi 与 eapi 的解密见 util.LinuxapiDecrypt 与 util.EapiDecrypt
+//
+// encSecKey 是对随机密钥做的无填充 RSA 加密，只有持有私钥才能还原，
+// 因此请求方需要使用 Server 生成的公钥（见 Server.NewClient）
+func decryptWeapi(key *rsa.PrivateKey, params, encSecKey string) (map[string]interface{}, error) {
+	encrypted, err := hex.DecodeString(encSecKey)
+	if err != nil {
+		return nil, fmt.Errorf("decode encSecKey: %w", err)
+	}
+	c := new(big.Int).SetBytes(encrypted)
+	secretKey := c.Exp(c, key.D, key.N).Bytes()
+	if len(secretKey) != 16 {
+		return nil, errors.New("encSecKey was not encrypted with the server public key")
+	}
+	reSecretKey := make([]byte, 16)
+	for i, b := range secretKey {
+		reSecretKey[15-i] = b
+	}
+
+	outer, err := base64.StdEncoding.DecodeString(params)
+	if err != nil {
+		return nil, fmt.Errorf("decode params: %w", err)
+	}
+	inner, err := aesCBCDecrypt(outer, reSecretKey)
+	if err != nil {
+		return nil, fmt.Errorf("decrypt params: %w", err)
+	}
+	text, err := base64.StdEncoding.DecodeString(string(inner))
+	if err != nil {
+		return nil, fmt.Errorf("decode params: %w", err)
+	}
+	text, err = aesCBCDecrypt(text, presetKey)
+	if err != nil {
+		return nil, fmt.Errorf("decrypt params: %w", err)
+	}
+	return unmarshalParams(text)
+}
+
+// aesCBCDecrypt 解密前校验长度，openssl.AesCBCDecrypt 在密文长度不是块大小的整数倍时会 panic
+func aesCBCDecrypt(encrypted, key []byte) ([]byte, error) {
+	if len(encrypted) == 0 || len(encrypted)%aes.BlockSize != 0 {
+		return nil, errors.New("ciphertext is not a multiple of the block size")
+	}
+	return openssl.AesCBCDecrypt(encrypted, key, iv, openssl.PKCS7_PADDING)
+}
+
+func unmarshalParams(text []byte) (map[string]interface{}, error) {
+	params := make(map[string]interface{})
+	decoder := json.NewDecoder(bytes.NewReader(text))
+	decoder.UseNumber()
+	if err := decoder.Decode(&params); err != nil {
+		return nil, fmt.Errorf("unmarshal params: %w", err)
+	}
+	return params, nil
+}
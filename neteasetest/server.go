@@ -0,0 +1,382 @@
+// Package neteasetest 提供一个进程内的网易云音乐接口模拟服务，
+// 能够解密 weapi、eapi、linuxapi 请求并按接口路径返回预置的响应，用于编写不依赖网络的测试。
+//
+//	server := neteasetest.NewServer()
+//	defer server.Close()
+//	server.HandleJSON("/api/point/dailyTask", `{"code":301,"msg":"需要登录"}`)
+//
+//	s := &service.YunbeiSigninService{Client: server.NewClient()}
+//	code, body := s.Signin()
+package neteasetest
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"embed"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/go-musicfox/netease-music/util"
+)
+
+//go:embed fixtures
+var fixtures embed.FS
+
+// 加密方式
+const (
+	CryptoNone     = ""
+	CryptoWeapi    = "weapi"
+	CryptoEapi     = "eapi"
+	CryptoLinuxapi = "linuxapi"
+)
+
+// Request 解密后的请求
+type Request struct {
+	Method string
+	// Path 归一化后的接口路径，weapi、eapi 前缀统一替换为 /api/，linuxapi 为转发的目标接口，如 /api/cloudsearch/pc
+	Path string
+	// Crypto 请求使用的加密方式
+	Crypto string
+	// Params 解密后的参数，eapi 请求中的 header 字段保存在 Header 中
+	Params map[string]interface{}
+	// Header eapi 请求参数中携带的 header
+	Header map[string]interface{}
+	// HTTP 原始请求，Body 已被读取
+	HTTP *http.Request
+}
+
+// Param 以字符串形式返回参数，不存在时返回空字符串
+func (r *Request) Param(name string) string {
+	v, ok := r.Params[name]
+	if !ok || v == nil {
+		return ""
+	}
+	if s, ok := v.(string); ok {
+		return s
+	}
+	return fmt.Sprint(v)
+}
+
+// Cookie 返回请求携带的 Cookie 值，不存在时返回空字符串
+func (r *Request) Cookie(name string) string {
+	if c, err := r.HTTP.Cookie(name); err == nil {
+		return c.Value
+	}
+	return ""
+}
+
+// Handler 处理解密后的请求
+type Handler func(w http.ResponseWriter, r *Request)
+
+// JSON 返回固定响应体的 Handler
+func JSON(body string) Handler {
+	return func(w http.ResponseWriter, r *Request) {
+		w.Header().Set("Content-Type", "application/json;charset=UTF-8")
+		_, _ = w.Write([]byte(body))
+	}
+}
+
+// Sequence 依次返回 bodies 中的响应，用完后一直返回最后一个，常用于模拟扫码登录等轮询接口
+func Sequence(bodies ...string) Handler {
+	var (
+		mu sync.Mutex
+		i  int
+	)
+	return func(w http.ResponseWriter, r *Request) {
+		mu.Lock()
+		body := bodies[i]
+		if i < len(bodies)-1 {
+			i++
+		}
+		mu.Unlock()
+		JSON(body)(w, r)
+	}
+}
+
+// Fixture 返回内置 fixtures 目录下名为 name 的响应，name 形如 api/cloudsearch/pc.json
+func Fixture(name string) Handler {
+	body, err := fixtures.ReadFile(path.Join("fixtures", name))
+	if err != nil {
+		panic(fmt.Sprintf("neteasetest: fixture %s not found", name))
+	}
+	return JSON(string(body))
+}
+
+// 登录、游客注册成功后服务下发的 Cookie
+const (
+	TestMusicU = "neteasetest-music-u"
+	TestCsrf   = "neteasetest-csrf"
+	TestMusicA = "neteasetest-music-a"
+)
+
+// Server 模拟网易云音乐接口的本地服务
+//
+// 创建时会按 fixtures 目录为常用接口注册默认响应，可通过 Handle 覆盖。
+// 路由查找顺序：完整路径；去掉末尾数字ID后的路径，如 /api/v1/album/123 匹配 /api/v1/album；
+// 以 "/" 结尾的最长前缀，如 "/api/v1/resource/comments/" 匹配所有评论请求。
+//
+// 登录接口返回 200 或 803 时服务会下发 MUSIC_U 与 __csrf Cookie，游客注册成功时下发 MUSIC_A，
+// 通过 RequireLogin 注册的接口在请求未携带 MUSIC_U 时返回 301
+type Server struct {
+	*httptest.Server
+
+	key          *rsa.PrivateKey
+	publicKeyPEM []byte
+
+	mu           sync.Mutex
+	routes       map[string]Handler
+	loginPaths   map[string]bool
+	requireLogin map[string]bool
+	requests     []*Request
+}
+
+var (
+	defaultLoginPaths   = []string{"/api/login", "/api/login/cellphone", "/api/login/qrcode/client/login", "/api/login/token/refresh"}
+	defaultRequireLogin = []string{"/api/point/dailyTask", "/api/login/token/refresh"}
+	guestPath           = "/api/register/anonimous"
+	trailingID          = regexp.MustCompile(`/\d*$`)
+)
+
+// NewServer 启动一个模拟服务，使用完毕后需调用 Close
+func NewServer() *Server {
+	key, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		panic(fmt.Sprintf("neteasetest: generate rsa key: %v", err))
+	}
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		panic(fmt.Sprintf("neteasetest: marshal rsa key: %v", err))
+	}
+
+	s := &Server{
+		key:          key,
+		publicKeyPEM: pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}),
+		routes:       make(map[string]Handler),
+		loginPaths:   make(map[string]bool),
+		requireLogin: make(map[string]bool),
+	}
+	for _, p := range defaultLoginPaths {
+		s.loginPaths[p] = true
+	}
+	s.RequireLogin(defaultRequireLogin...)
+	_ = fs.WalkDir(fixtures, "fixtures", func(name string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !strings.HasSuffix(name, ".json") {
+			return err
+		}
+		rel := strings.TrimPrefix(name, "fixtures/")
+		s.routes["/"+strings.TrimSuffix(rel, ".json")] = Fixture(rel)
+		return nil
+	})
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// NewClient 返回一个请求都会发往该服务的 Client，每次调用返回的 Client 拥有独立的 CookieJar
+func (s *Server) NewClient() *util.Client {
+	return &util.Client{
+		BaseURLs: map[string]string{
+			util.HostMusic:        s.URL,
+			util.HostInterface:    s.URL,
+			util.HostInterface3:   s.URL,
+			util.HostClientLogUsf: s.URL,
+		},
+		WeapiPublicKey: s.publicKeyPEM,
+	}
+}
+
+// Handle 为接口路径注册 Handler，会覆盖已有的注册
+func (s *Server) Handle(path string, h Handler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.routes[path] = h
+}
+
+// HandleJSON 为接口路径注册固定的响应体
+func (s *Server) HandleJSON(path, body string) {
+	s.Handle(path, JSON(body))
+}
+
+// RequireLogin 使请求未携带 MUSIC_U 时这些接口返回 301
+func (s *Server) RequireLogin(paths ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, p := range paths {
+		s.requireLogin[p] = true
+	}
+}
+
+// Login 在 client 的 CookieJar 中写入服务认可的登录 Cookie
+func (s *Server) Login(client *util.Client) {
+	util.AddCookiesToJar(client.CookieJar(), map[string]string{
+		"MUSIC_U": TestMusicU,
+		"__csrf":  TestCsrf,
+	}, "https://"+util.HostMusic)
+}
+
+// Requests 返回服务收到的全部请求
+func (s *Server) Requests() []*Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]*Request(nil), s.requests...)
+}
+
+// LastRequest 返回最近一次发往 path 的请求，没有时返回 nil
+func (s *Server) LastRequest(path string) *Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := len(s.requests) - 1; i >= 0; i-- {
+		if s.requests[i].Path == path {
+			return s.requests[i]
+		}
+	}
+	return nil
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	req, err := s.decode(r)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		writeError(w, 400, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	s.requests = append(s.requests, req)
+	h := s.route(req.Path)
+	login, requireLogin := s.loginPaths[req.Path], s.requireLogin[req.Path]
+	s.mu.Unlock()
+
+	switch {
+	case requireLogin && req.Cookie("MUSIC_U") == "":
+		writeError(w, 301, "需要登录")
+	case h == nil:
+		w.WriteHeader(http.StatusNotFound)
+		writeError(w, 404, "neteasetest: no handler for "+req.Path)
+	case login:
+		serveLogin(w, req, h, "MUSIC_U", TestMusicU, "__csrf", TestCsrf)
+	case req.Path == guestPath:
+		serveLogin(w, req, h, "MUSIC_A", TestMusicA)
+	default:
+		h(w, req)
+	}
+}
+
+// serveLogin 登录成功时下发登录 Cookie，cookies 为依次排列的名称与值
+func serveLogin(w http.ResponseWriter, req *Request, h Handler, cookies ...string) {
+	rec := httptest.NewRecorder()
+	h(rec, req)
+	var body struct {
+		Code float64 `json:"code"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err == nil && (body.Code == 200 || body.Code == 803) {
+		for i := 0; i+1 < len(cookies); i += 2 {
+			http.SetCookie(w, &http.Cookie{Name: cookies[i], Value: cookies[i+1], Path: "/", MaxAge: 15 * 24 * 3600})
+		}
+	}
+	for k, v := range rec.Header() {
+		w.Header()[k] = v
+	}
+	w.WriteHeader(rec.Code)
+	_, _ = w.Write(rec.Body.Bytes())
+}
+
+// route 查找路径对应的 Handler，调用方需持有 s.mu
+func (s *Server) route(p string) Handler {
+	if h, ok := s.routes[p]; ok {
+		return h
+	}
+	if h, ok := s.routes[trailingID.ReplaceAllString(p, "")]; ok {
+		return h
+	}
+	var prefixes []string
+	for k := range s.routes {
+		if strings.HasSuffix(k, "/") && strings.HasPrefix(p, k) {
+			prefixes = append(prefixes, k)
+		}
+	}
+	if len(prefixes) == 0 {
+		return nil
+	}
+	// 最长前缀优先
+	sort.Slice(prefixes, func(i, j int) bool { return len(prefixes[i]) > len(prefixes[j]) })
+	return s.routes[prefixes[0]]
+}
+
+func (s *Server) decode(r *http.Request) (*Request, error) {
+	if err := r.ParseForm(); err != nil {
+		return nil, err
+	}
+	req := &Request{
+		Method: r.Method,
+		Path:   r.URL.Path,
+		Params: make(map[string]interface{}),
+		HTTP:   r,
+	}
+
+	var err error
+	switch {
+	case r.URL.Path == "/api/linux/forward":
+		req.Crypto = CryptoLinuxapi
+		forward, err := util.LinuxapiDecrypt(r.PostForm.Get("eparams"))
+		if err != nil {
+			return nil, err
+		}
+		req.Method, _ = forward["method"].(string)
+		req.Params, _ = forward["params"].(map[string]interface{})
+		if req.Params == nil {
+			req.Params = make(map[string]interface{})
+		}
+		if target, ok := forward["url"].(string); ok {
+			if u, err := url.Parse(target); err == nil {
+				req.Path = u.Path
+			}
+		}
+	case strings.HasPrefix(r.URL.Path, "/eapi/"):
+		var target string
+		req.Crypto = CryptoEapi
+		target, req.Params, err = util.EapiDecrypt(r.PostForm.Get("params"))
+		if err != nil {
+			return nil, err
+		}
+		if target != "" {
+			req.Path = target
+		}
+		if header, ok := req.Params["header"].(map[string]interface{}); ok {
+			req.Header = header
+			delete(req.Params, "header")
+		}
+	case r.PostForm.Get("encSecKey") != "":
+		req.Crypto = CryptoWeapi
+		req.Params, err = decryptWeapi(s.key, r.PostForm.Get("params"), r.PostForm.Get("encSecKey"))
+		if err != nil {
+			return nil, err
+		}
+	default:
+		for k := range r.Form {
+			req.Params[k] = r.Form.Get(k)
+		}
+	}
+
+	for _, prefix := range []string{"/weapi/", "/eapi/"} {
+		if strings.HasPrefix(req.Path, prefix) {
+			req.Path = "/api/" + strings.TrimPrefix(req.Path, prefix)
+		}
+	}
+	return req, nil
+}
+
+func writeError(w http.ResponseWriter, code int, msg string) {
+	body, _ := json.Marshal(map[string]interface{}{"code": code, "msg": msg})
+	_, _ = w.Write(body)
+}
@@ -0,0 +1,126 @@
+package neteasetest
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/go-musicfox/netease-music/util"
+)
+
+func TestServer_DecryptRequests(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	client := server.NewClient()
+
+	cases := []struct {
+		crypto string
+		url    string
+		path   string
+	}{
+		{CryptoWeapi, "https://music.163.com/api/cloudsearch/pc", "/api/cloudsearch/pc"},
+		{CryptoLinuxapi, "https://music.163.com/api/song/enhance/player/url", "/api/song/enhance/player/url"},
+		{CryptoEapi, "https://music.163.com/api/v3/song/detail", "/api/v3/song/detail"},
+	}
+	for _, c := range cases {
+		options := &util.Options{Crypto: c.crypto, Url: c.path}
+		code, body, _, err := client.CreateRequestContext(context.Background(), "POST", c.url, map[string]string{"id": "405998841"}, options)
+		if err != nil || code != 200 {
+			t.Fatalf("%s: unexpected result: code %f, body %s, err %v", c.crypto, code, body, err)
+		}
+		req := server.LastRequest(c.path)
+		if req == nil {
+			t.Fatalf("%s: request to %s not recorded", c.crypto, c.path)
+		}
+		if req.Crypto != c.crypto || req.Param("id") != "405998841" {
+			t.Errorf("%s: unexpected decoded request: %+v", c.crypto, req)
+		}
+	}
+
+	if req := server.LastRequest("/api/v3/song/detail"); req.Header["os"] != "ios" {
+		t.Errorf("eapi header not decoded: %v", req.Header)
+	}
+}
+
+func TestServer_CallWeapi(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	client := server.NewClient()
+
+	code, _, err := client.CallWeapi("https://music.163.com/weapi/song/enhance/player/url/v1", map[string]interface{}{"ids": "[405998841]", "level": "lossless"})
+	if err != nil || code != 200 {
+		t.Fatalf("unexpected result: code %f, err %v", code, err)
+	}
+	if req := server.LastRequest("/api/song/enhance/player/url/v1"); req == nil || req.Param("level") != "lossless" {
+		t.Fatalf("unexpected decoded request: %+v", req)
+	}
+}
+
+func TestServer_Routing(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	server.HandleJSON("/api/v1/resource/comments/", `{"code":200,"comments":[]}`)
+	client := server.NewClient()
+
+	for _, url := range []string{
+		"https://music.163.com/weapi/v1/album/147779282",
+		"https://music.163.com/weapi/v1/resource/comments/R_SO_4_405998841",
+	} {
+		if code, body, _, err := client.CreateRequestContext(context.Background(), "POST", url, map[string]string{}, &util.Options{Crypto: "weapi"}); err != nil || code != 200 {
+			t.Errorf("%s: unexpected result: code %f, body %s, err %v", url, code, body, err)
+		}
+	}
+
+	code, _, _, err := client.CreateRequestContext(context.Background(), "POST", "https://music.163.com/weapi/not/exists", map[string]string{}, &util.Options{Crypto: "weapi"})
+	var apiErr *util.APIError
+	if code != 404 || !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound {
+		t.Errorf("unexpected result for unknown path: code %f, err %v", code, err)
+	}
+}
+
+func TestServer_Login(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	client := server.NewClient()
+
+	_, _, _, err := client.CreateRequestContext(context.Background(), "POST", "https://music.163.com/api/point/dailyTask", map[string]string{"type": "0"}, &util.Options{Crypto: "weapi"})
+	if !errors.Is(err, util.ErrNotLoggedIn) {
+		t.Fatalf("expected not logged in, got %v", err)
+	}
+
+	code, _, _, err := client.CreateRequestContext(context.Background(), "POST", "https://music.163.com/weapi/login/cellphone", map[string]string{"phone": "13800000000"}, &util.Options{Crypto: "weapi"})
+	if err != nil || code != 200 {
+		t.Fatalf("login failed: code %f, err %v", code, err)
+	}
+	if token := util.GetCsrfToken(client.CookieJar()); token != TestCsrf {
+		t.Fatalf("login cookies not stored: %q", token)
+	}
+
+	code, _, _, err = client.CreateRequestContext(context.Background(), "POST", "https://music.163.com/api/point/dailyTask", map[string]string{"type": "0"}, &util.Options{Crypto: "weapi"})
+	if err != nil || code != 200 {
+		t.Fatalf("unexpected result after login: code %f, err %v", code, err)
+	}
+}
+
+func TestServer_MalformedWeapi(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	// 使用服务端公钥加密的合法 encSecKey，params 的长度不是块大小的整数倍
+	secret := new(big.Int).SetBytes([]byte("0123456789abcdef"))
+	encSecKey := hex.EncodeToString(secret.Exp(secret, big.NewInt(int64(server.key.E)), server.key.N).Bytes())
+	for _, params := range []string{"", "AAAA"} {
+		resp, err := http.PostForm(server.URL+"/weapi/song/detail", url.Values{"params": {params}, "encSecKey": {encSecKey}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Errorf("params %q: status = %d", params, resp.StatusCode)
+		}
+	}
+}
@@ -0,0 +1,223 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/buger/jsonparser"
+	"github.com/go-musicfox/netease-music/util"
+)
+
+// ErrAccountNotFound AccountManager 中没有该名称的账号
+var ErrAccountNotFound = errors.New("netease: 账号不存在")
+
+// Account AccountManager 管理的一个账号
+type Account struct {
+	// Name 账号在本地的名称，由调用方指定，在同一个 AccountManager 中唯一
+	Name     string `json:"name"`
+	UserID   int64  `json:"userId"`
+	Nickname string `json:"nickname"`
+
+	client *util.Client
+}
+
+// Client 返回该账号使用的 Client，可以直接赋给各 service 的 Client 字段
+func (a *Account) Client() *util.Client {
+	return a.client
+}
+
+// AccountManager 同时管理多个网易云账号
+//
+// 每个账号使用独立的 Client，因而拥有独立的 CookieJar 与设备标识。
+// Save 会将所有账号的会话加密保存到一个文件中，之后通过 Load 恢复
+type AccountManager struct {
+	// NewClient 为导入的账号创建 Client，可在其中设置代理、缓存等，为 nil 时使用 util.NewClient(nil)
+	NewClient func() *util.Client
+
+	mu       sync.Mutex
+	accounts []*Account
+	active   string
+}
+
+// Add 以 name 添加一个已登录的 client，通过 UserAccountService 获取用户ID与昵称，未登录时返回 util.ErrNotLoggedIn
+//
+// 已有同名账号时替换该账号。第一个添加的账号会成为当前账号
+func (m *AccountManager) Add(ctx context.Context, name string, client *util.Client) (*Account, error) {
+	if client == nil {
+		return nil, errors.New("netease: client 不能为空")
+	}
+	_, body, err := (&UserAccountService{Client: client}).AccountInfoContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	account := &Account{Name: name, client: client}
+	account.UserID, _ = jsonparser.GetInt(body, "account", "id")
+	account.Nickname, _ = jsonparser.GetString(body, "profile", "nickname")
+	if account.UserID <= 0 {
+		return nil, util.ErrNotLoggedIn
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.put(account)
+	return account, nil
+}
+
+// AddSession 以 name 添加一个保存的会话，会话导入到新建的 Client 中，见 Add
+func (m *AccountManager) AddSession(ctx context.Context, name string, s *util.Session) (*Account, error) {
+	client := m.newClient()
+	client.ImportSession(s)
+	return m.Add(ctx, name, client)
+}
+
+// put 添加或替换账号，调用方需持有 m.mu
+func (m *AccountManager) put(account *Account) {
+	for i, a := range m.accounts {
+		if a.Name == account.Name {
+			m.accounts[i] = account
+			return
+		}
+	}
+	m.accounts = append(m.accounts, account)
+	if m.active == "" {
+		m.active = account.Name
+	}
+}
+
+func (m *AccountManager) newClient() *util.Client {
+	if m.NewClient != nil {
+		return m.NewClient()
+	}
+	return util.NewClient(nil)
+}
+
+// Accounts 按添加顺序返回所有账号
+func (m *AccountManager) Accounts() []*Account {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]*Account(nil), m.accounts...)
+}
+
+// Get 返回名为 name 的账号，不存在时返回 nil
+func (m *AccountManager) Get(name string) *Account {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.get(name)
+}
+
+func (m *AccountManager) get(name string) *Account {
+	for _, a := range m.accounts {
+		if a.Name == name {
+			return a
+		}
+	}
+	return nil
+}
+
+// Active 返回当前账号，没有账号时返回 nil
+func (m *AccountManager) Active() *Account {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.get(m.active)
+}
+
+// Client 返回当前账号的 Client，没有账号时返回 nil，此时 service 使用默认 Client
+func (m *AccountManager) Client() *util.Client {
+	if a := m.Active(); a != nil {
+		return a.client
+	}
+	return nil
+}
+
+// Switch 将 name 设为当前账号
+func (m *AccountManager) Switch(name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.get(name) == nil {
+		return fmt.Errorf("%w: %s", ErrAccountNotFound, name)
+	}
+	m.active = name
+	return nil
+}
+
+// Do 以 name 账号的身份执行 fn，不影响当前账号
+func (m *AccountManager) Do(name string, fn func(client *util.Client) error) error {
+	a := m.Get(name)
+	if a == nil {
+		return fmt.Errorf("%w: %s", ErrAccountNotFound, name)
+	}
+	return fn(a.client)
+}
+
+// Remove 注销并移除名为 name 的账号
+//
+// 先通过 LogoutService 注销，无论注销是否成功都会清除该账号在本地的登录凭证（见 util.Client.ClearSession），
+// 注销失败时返回其错误。移除的是当前账号时，当前账号变为空
+func (m *AccountManager) Remove(ctx context.Context, name string) error {
+	m.mu.Lock()
+	var account *Account
+	for i, a := range m.accounts {
+		if a.Name == name {
+			account = a
+			m.accounts = append(m.accounts[:i:i], m.accounts[i+1:]...)
+			break
+		}
+	}
+	if account != nil && m.active == name {
+		m.active = ""
+	}
+	m.mu.Unlock()
+	if account == nil {
+		return fmt.Errorf("%w: %s", ErrAccountNotFound, name)
+	}
+
+	_, _, err := (&LogoutService{Client: account.client}).LogoutContext(ctx)
+	account.client.ClearSession()
+	return err
+}
+
+// accountFile Save 保存的内容
+type accountFile struct {
+	Active   string         `json:"active"`
+	Accounts []savedAccount `json:"accounts"`
+}
+
+type savedAccount struct {
+	Account
+	Session *util.Session `json:"session"`
+}
+
+// Save 将所有账号的会话使用 passphrase 加密后写入 path，见 util.SaveEncrypted
+func (m *AccountManager) Save(path, passphrase string) error {
+	m.mu.Lock()
+	f := accountFile{Active: m.active}
+	for _, a := range m.accounts {
+		f.Accounts = append(f.Accounts, savedAccount{Account: *a, Session: a.client.ExportSession()})
+	}
+	m.mu.Unlock()
+	return util.SaveEncrypted(path, &f, passphrase)
+}
+
+// Load 从 Save 保存的文件中恢复账号，替换当前管理的所有账号，不会发出网络请求
+func (m *AccountManager) Load(path, passphrase string) error {
+	var f accountFile
+	if err := util.LoadEncrypted(path, passphrase, &f); err != nil {
+		return err
+	}
+	accounts := make([]*Account, 0, len(f.Accounts))
+	for _, saved := range f.Accounts {
+		account := saved.Account
+		account.client = m.newClient()
+		if saved.Session != nil {
+			account.client.ImportSession(saved.Session)
+		}
+		accounts = append(accounts, &account)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.accounts, m.active = accounts, f.Active
+	return nil
+}
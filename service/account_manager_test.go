@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-musicfox/netease-music/neteasetest"
+	"github.com/go-musicfox/netease-music/util"
+)
+
+func TestAccountManager(t *testing.T) {
+	server := neteasetest.NewServer()
+	defer server.Close()
+	users := map[string]int{"u-alice": 100, "u-bob": 200}
+	server.Handle("/api/nuser/account/get", func(w http.ResponseWriter, r *neteasetest.Request) {
+		id, ok := users[r.Cookie("MUSIC_U")]
+		if !ok {
+			neteasetest.JSON(`{"code":200,"account":null,"profile":null}`)(w, r)
+			return
+		}
+		neteasetest.JSON(fmt.Sprintf(`{"code":200,"account":{"id":%d},"profile":{"userId":%d,"nickname":"user%d"}}`, id, id, id))(w, r)
+	})
+	login := func(musicU string) *util.Client {
+		client := server.NewClient()
+		_ = util.AddCookiesToJar(client.CookieJar(), map[string]string{"MUSIC_U": musicU, "__csrf": "csrf-" + musicU}, "https://music.163.com")
+		return client
+	}
+
+	ctx := context.Background()
+	m := &AccountManager{NewClient: server.NewClient}
+	alice, err := m.Add(ctx, "alice", login("u-alice"))
+	if err != nil || alice.UserID != 100 || alice.Nickname != "user100" {
+		t.Fatalf("add alice: %+v, %v", alice, err)
+	}
+	if _, err := m.Add(ctx, "bob", login("u-bob")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.Add(ctx, "guest", server.NewClient()); !errors.Is(err, util.ErrNotLoggedIn) {
+		t.Fatalf("add logged out client: %v", err)
+	}
+	if m.Active().Name != "alice" {
+		t.Fatalf("active: %+v", m.Active())
+	}
+	if err := m.Switch("bob"); err != nil || m.Client() != m.Get("bob").Client() {
+		t.Fatalf("switch: %v", err)
+	}
+
+	// 保存后恢复
+	path := filepath.Join(t.TempDir(), "accounts")
+	if err := m.Save(path, "secret"); err != nil {
+		t.Fatal(err)
+	}
+	restored := &AccountManager{NewClient: server.NewClient}
+	if err := restored.Load(path, "secret"); err != nil {
+		t.Fatal(err)
+	}
+	if len(restored.Accounts()) != 2 || restored.Active().Name != "bob" || restored.Get("alice").Nickname != "user100" {
+		t.Fatalf("restored: %+v", restored.Accounts())
+	}
+	err = restored.Do("alice", func(client *util.Client) error {
+		_, _, err := (&UserAccountService{Client: client}).AccountInfoContext(ctx)
+		if id := client.ExportSession().UserID; id != 100 {
+			return fmt.Errorf("called as %d", id)
+		}
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 移除账号时注销并清除本地凭证
+	client := restored.Get("alice").Client()
+	server.HandleJSON("/api/homepage/dragon/ball/static", `{"code":200,"data":[]}`)
+	requestAs := func() {
+		t.Helper()
+		if _, _, err := (&SongDetailService{Client: client, Ids: "405998841"}).SongDetailContext(ctx); err != nil {
+			t.Fatal(err)
+		}
+		if _, _, err := (&HomepageDragonBallService{Client: client}).HomepageDragonBallContext(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+	requestAs()
+	if err := restored.Remove(ctx, "alice"); err != nil {
+		t.Fatal(err)
+	}
+	if req := server.LastRequest("/api/logout"); req == nil || req.Cookie("MUSIC_U") != "u-alice" {
+		t.Fatalf("logout request: %+v", req)
+	}
+	if got := util.GetCsrfToken(client.CookieJar()); got != "" {
+		t.Errorf("csrf token left: %q", got)
+	}
+	for _, c := range client.ExportSession().Cookies {
+		if c.Name == "MUSIC_U" {
+			t.Errorf("MUSIC_U left on %s", c.Host)
+		}
+	}
+	// 移除后该 Client 发出的请求不再携带登录凭证，包括移除前请求过的接口
+	requestAs()
+	for _, path := range []string{"/api/v3/song/detail", "/api/homepage/dragon/ball/static"} {
+		req := server.LastRequest(path)
+		if req == nil {
+			t.Fatalf("request to %s not recorded", path)
+		}
+		for _, name := range []string{"MUSIC_U", "__csrf"} {
+			if v := req.Cookie(name); v != "" {
+				t.Errorf("%s: %s sent after Remove: %q", path, name, v)
+			}
+			if v, _ := req.Header[name].(string); v != "" {
+				t.Errorf("%s: eapi header %s sent after Remove: %q", path, name, v)
+			}
+		}
+	}
+	if err := restored.Switch("alice"); !errors.Is(err, ErrAccountNotFound) {
+		t.Fatalf("switch to removed account: %v", err)
+	}
+	if restored.Active().Name != "bob" {
+		t.Fatalf("active changed: %+v", restored.Active())
+	}
+}
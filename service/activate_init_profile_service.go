@@ -1,14 +1,23 @@
 package service
 
 import (
+	"context"
+
 	"github.com/go-musicfox/netease-music/util"
 )
 
 type ActivateInitProfileService struct {
 	Nickname string `json:"nickname" form:"nickname"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *ActivateInitProfileService) ActivateInitProfile() (float64, []byte) {
+	code, reBody, _ := service.ActivateInitProfileContext(context.Background())
+	return code, reBody
+}
+
+func (service *ActivateInitProfileService) ActivateInitProfileContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto: "eapi",
@@ -17,7 +26,7 @@ func (service *ActivateInitProfileService) ActivateInitProfile() (float64, []byt
 	data := make(map[string]string)
 	data["nickname"] = service.Nickname
 
-	code, reBody, _ := util.CreateRequest("POST", `http://music.163.com/eapi/activate/initProfile`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `http://music.163.com/eapi/activate/initProfile`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
@@ -1,21 +1,30 @@
 package service
 
 import (
+	"context"
+
 	"github.com/go-musicfox/netease-music/util"
 )
 
 type AlbumDetailDynamicService struct {
 	ID string `json:"id" form:"id"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *AlbumDetailDynamicService) AlbumDetailDynamic() (float64, []byte) {
+	code, reBody, _ := service.AlbumDetailDynamicContext(context.Background())
+	return code, reBody
+}
+
+func (service *AlbumDetailDynamicService) AlbumDetailDynamicContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto: "weapi",
 	}
 	data := make(map[string]string)
 	data["id"] = service.ID
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/api/album/detail/dynamic`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/api/album/detail/dynamic`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
@@ -1,6 +1,8 @@
 package service
 
 import (
+	"context"
+
 	"github.com/go-musicfox/netease-music/util"
 )
 
@@ -9,9 +11,16 @@ type AlbumListService struct {
 	Limit  string `json:"limit" form:"limit"`
 	Offset string `json:"offset" form:"offset"`
 	Type   string `json:"type" form:"type"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *AlbumListService) AlbumList() (float64, []byte) {
+	code, reBody, _ := service.AlbumListContext(context.Background())
+	return code, reBody
+}
+
+func (service *AlbumListService) AlbumListContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto: "weapi",
@@ -34,7 +43,7 @@ func (service *AlbumListService) AlbumList() (float64, []byte) {
 	}
 	data["order"] = "true"
 	data["type"] = service.Type
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/weapi/vipmall/albumproduct/list`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/weapi/vipmall/albumproduct/list`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
@@ -1,6 +1,8 @@
 package service
 
 import (
+	"context"
+
 	"github.com/go-musicfox/netease-music/util"
 )
 
@@ -8,9 +10,16 @@ type AlbumListStyleService struct {
 	Area   string `json:"area" form:"area"`
 	Limit  string `json:"limit" form:"limit"`
 	Offset string `json:"offset" form:"offset"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *AlbumListStyleService) AlbumListStyle() (float64, []byte) {
+	code, reBody, _ := service.AlbumListStyleContext(context.Background())
+	return code, reBody
+}
+
+func (service *AlbumListStyleService) AlbumListStyleContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto: "weapi",
@@ -31,7 +40,7 @@ func (service *AlbumListStyleService) AlbumListStyle() (float64, []byte) {
 	}
 	data["order"] = "true"
 	data["area"] = service.Area
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/weapi/vipmall/appalbum/album/style`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/weapi/vipmall/appalbum/album/style`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
@@ -1,7 +1,7 @@
 package service
 
 import (
-	"net/http"
+	"context"
 
 	"github.com/go-musicfox/netease-music/util"
 )
@@ -10,15 +10,20 @@ type AlbumNewService struct {
 	Area   string `json:"area" form:"area"` //ALL:全部,ZH:华语,EA:欧美,KR:韩国,JP:日本
 	Limit  string `json:"limit" form:"limit"`
 	Offset string `json:"offset" form:"offset"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *AlbumNewService) AlbumNew() (float64, []byte) {
+	code, reBody, _ := service.AlbumNewContext(context.Background())
+	return code, reBody
+}
 
-	cookiesOS := &http.Cookie{Name: "os", Value: "pc"}
+func (service *AlbumNewService) AlbumNewContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto:  "weapi",
-		Cookies: []*http.Cookie{cookiesOS},
+		Profile: util.WebProfile(),
 	}
 	data := make(map[string]string)
 
@@ -36,7 +41,7 @@ func (service *AlbumNewService) AlbumNew() (float64, []byte) {
 	data["offset"] = service.Offset
 	data["total"] = "true"
 
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/weapi/album/new`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/weapi/album/new`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
@@ -1,24 +1,29 @@
 package service
 
 import (
-	"net/http"
+	"context"
 
 	"github.com/go-musicfox/netease-music/util"
 )
 
-type AlbumNewestService struct{}
+type AlbumNewestService struct {
+	Client *util.Client `json:"-" form:"-"`
+}
 
 func (service *AlbumNewestService) AlbumNewest() (float64, []byte) {
+	code, reBody, _ := service.AlbumNewestContext(context.Background())
+	return code, reBody
+}
 
-	cookiesOS := &http.Cookie{Name: "os", Value: "pc"}
+func (service *AlbumNewestService) AlbumNewestContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto:  "weapi",
-		Cookies: []*http.Cookie{cookiesOS},
+		Profile: util.WebProfile(),
 	}
 	data := make(map[string]string)
 
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/api/discovery/newAlbum`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/api/discovery/newAlbum`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
@@ -1,20 +1,29 @@
 package service
 
 import (
+	"context"
+
 	"github.com/go-musicfox/netease-music/util"
 )
 
 type AlbumService struct {
 	ID string `json:"id" form:"id"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *AlbumService) Album() (float64, []byte) {
+	code, reBody, _ := service.AlbumContext(context.Background())
+	return code, reBody
+}
+
+func (service *AlbumService) AlbumContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto: "weapi",
 	}
 	data := make(map[string]string)
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/weapi/v1/album/`+service.ID, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/weapi/v1/album/`+service.ID, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
@@ -4,14 +4,19 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/go-musicfox/netease-music/neteasetest"
 	"github.com/go-musicfox/netease-music/util"
 )
 
 func TestAlbumService_Album(t *testing.T) {
-	util.UNMSwitch = true
-	util.Sources = []string{"kuwo"}
+	server := neteasetest.NewServer()
+	defer server.Close()
+
+	client := server.NewClient()
+	client.UNM = &util.UNMConfig{Enable: true, Sources: []string{"kuwo"}}
 	service := &AlbumService{
-		ID: "147779282",
+		Client: client,
+		ID:     "147779282",
 	}
 	code, resp := service.Album()
 	fmt.Println(code, string(resp))
@@ -1,6 +1,8 @@
 package service
 
 import (
+	"context"
+
 	"github.com/go-musicfox/netease-music/util"
 )
 
@@ -10,9 +12,16 @@ type AlbumSongsaleboardService struct {
 	Offset    string `json:"offset" form:"offset"`
 	Type      string `json:"type" form:"type"`
 	Year      string `json:"year" form:"year"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *AlbumSongsaleboardService) AlbumSongsaleboard() (float64, []byte) {
+	code, reBody, _ := service.AlbumSongsaleboardContext(context.Background())
+	return code, reBody
+}
+
+func (service *AlbumSongsaleboardService) AlbumSongsaleboardContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto: "weapi",
@@ -38,7 +47,7 @@ func (service *AlbumSongsaleboardService) AlbumSongsaleboard() (float64, []byte)
 	if service.Type == "year" {
 		data["year"] = service.Year
 	}
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/api/feealbum/songsaleboard/`+service.Type+"/type", data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/api/feealbum/songsaleboard/`+service.Type+"/type", data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
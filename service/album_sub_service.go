@@ -1,15 +1,24 @@
 package service
 
 import (
+	"context"
+
 	"github.com/go-musicfox/netease-music/util"
 )
 
 type AlbumSubService struct {
 	ID string `json:"id" form:"id"`
 	T  string `json:"t" form:"t"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *AlbumSubService) AlbumSub() (float64, []byte) {
+	code, reBody, _ := service.AlbumSubContext(context.Background())
+	return code, reBody
+}
+
+func (service *AlbumSubService) AlbumSubContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto: "weapi",
@@ -22,7 +31,7 @@ func (service *AlbumSubService) AlbumSub() (float64, []byte) {
 	}
 	data["id"] = service.ID
 
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/api/album/`+service.T, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/api/album/`+service.T, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
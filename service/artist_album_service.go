@@ -1,7 +1,7 @@
 package service
 
 import (
-	"net/http"
+	"context"
 
 	"github.com/go-musicfox/netease-music/util"
 )
@@ -10,15 +10,20 @@ type ArtistAlbumService struct {
 	ID     string `json:"id" form:"id"`
 	Limit  string `json:"limit" form:"limit"`
 	Offset string `json:"offset" form:"offset"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *ArtistAlbumService) ArtistAlbum() (float64, []byte) {
+	code, reBody, _ := service.ArtistAlbumContext(context.Background())
+	return code, reBody
+}
 
-	cookiesOS := &http.Cookie{Name: "os", Value: "pc"}
+func (service *ArtistAlbumService) ArtistAlbumContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto:  "weapi",
-		Cookies: []*http.Cookie{cookiesOS},
+		Profile: util.WebProfile(),
 	}
 	data := make(map[string]string)
 	if service.Limit == "" {
@@ -32,7 +37,7 @@ func (service *ArtistAlbumService) ArtistAlbum() (float64, []byte) {
 		data["offset"] = service.Offset
 	}
 	data["total"] = "true"
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/weapi/artist/albums/`+service.ID, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/weapi/artist/albums/`+service.ID, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
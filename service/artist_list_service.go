@@ -1,6 +1,7 @@
 package service
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
@@ -30,9 +31,16 @@ type ArtistListService struct {
 	Offset  string `json:"offset" form:"offset"`
 	Area    string `json:"area" form:"area"`
 	Initial string `json:"initial" form:"initial"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *ArtistListService) ArtistList() (float64, []byte) {
+	code, reBody, _ := service.ArtistListContext(context.Background())
+	return code, reBody
+}
+
+func (service *ArtistListService) ArtistListContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto: "weapi",
@@ -61,7 +69,7 @@ func (service *ArtistListService) ArtistList() (float64, []byte) {
 		data["initial"] = fmt.Sprintf("%v", strings.ToUpper(service.Initial)[0])
 	}
 
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/api/v1/artist/list`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/api/v1/artist/list`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
@@ -1,6 +1,8 @@
 package service
 
 import (
+	"context"
+
 	"github.com/go-musicfox/netease-music/util"
 )
 
@@ -8,9 +10,16 @@ type ArtistMvService struct {
 	ID     string `json:"id" form:"id"`
 	Limit  string `json:"limit" form:"limit"`
 	Offset string `json:"offset" form:"offset"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *ArtistMvService) ArtistMv() (float64, []byte) {
+	code, reBody, _ := service.ArtistMvContext(context.Background())
+	return code, reBody
+}
+
+func (service *ArtistMvService) ArtistMvContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto: "weapi",
@@ -28,7 +37,7 @@ func (service *ArtistMvService) ArtistMv() (float64, []byte) {
 		data["offset"] = service.Offset
 	}
 	data["total"] = "true"
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/weapi/artist/mvs`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/weapi/artist/mvs`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
@@ -1,7 +1,7 @@
 package service
 
 import (
-	"net/http"
+	"context"
 
 	"github.com/go-musicfox/netease-music/util"
 )
@@ -11,15 +11,20 @@ type ArtistSongsService struct {
 	Limit  string `json:"limit" form:"limit"`
 	Offset string `json:"offset" form:"offset"`
 	Order  string `json:"order" form:"order"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *ArtistSongsService) ArtistSongs() (float64, []byte) {
+	code, reBody, _ := service.ArtistSongsContext(context.Background())
+	return code, reBody
+}
 
-	cookiesOS := &http.Cookie{Name: "os", Value: "pc"}
+func (service *ArtistSongsService) ArtistSongsContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto:  "weapi",
-		Cookies: []*http.Cookie{cookiesOS},
+		Profile: util.WebProfile(),
 	}
 	data := make(map[string]string)
 	data["id"] = service.ID
@@ -40,7 +45,7 @@ func (service *ArtistSongsService) ArtistSongs() (float64, []byte) {
 	}
 	data["work_type"] = "1"
 	data["private_cloud"] = "true"
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/api/v1/artist/songs`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/api/v1/artist/songs`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
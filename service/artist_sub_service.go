@@ -1,15 +1,24 @@
 package service
 
 import (
+	"context"
+
 	"github.com/go-musicfox/netease-music/util"
 )
 
 type ArtistSubService struct {
 	T  string `json:"t" form:"t"`
 	Id string `json:"id" form:"id"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *ArtistSubService) ArtistSub() (float64, []byte) {
+	code, reBody, _ := service.ArtistSubContext(context.Background())
+	return code, reBody
+}
+
+func (service *ArtistSubService) ArtistSubContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto: "weapi",
@@ -25,7 +34,7 @@ func (service *ArtistSubService) ArtistSub() (float64, []byte) {
 	data["artistId"] = service.Id
 	data["artistIds"] = "[" + service.Id + "]"
 
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/weapi/artist/`+service.T, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/weapi/artist/`+service.T, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
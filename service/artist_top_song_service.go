@@ -1,14 +1,23 @@
 package service
 
 import (
+	"context"
+
 	"github.com/go-musicfox/netease-music/util"
 )
 
 type ArtistTopSongService struct {
 	Id string `json:"id" form:"id"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *ArtistTopSongService) ArtistTopSong() (float64, []byte) {
+	code, reBody, _ := service.ArtistTopSongContext(context.Background())
+	return code, reBody
+}
+
+func (service *ArtistTopSongService) ArtistTopSongContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto: "weapi",
@@ -17,7 +26,7 @@ func (service *ArtistTopSongService) ArtistTopSong() (float64, []byte) {
 
 	data["id"] = service.Id
 
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/api/artist/top/song`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/api/artist/top/song`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
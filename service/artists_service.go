@@ -1,14 +1,23 @@
 package service
 
 import (
+	"context"
+
 	"github.com/go-musicfox/netease-music/util"
 )
 
 type ArtistsService struct {
 	ID string `json:"id" form:"id"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *ArtistsService) Artists() (float64, []byte) {
+	code, reBody, _ := service.ArtistsContext(context.Background())
+	return code, reBody
+}
+
+func (service *ArtistsService) ArtistsContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto: "weapi",
@@ -16,7 +25,7 @@ func (service *ArtistsService) Artists() (float64, []byte) {
 	data := make(map[string]string)
 	data["id"] = service.ID
 
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/weapi/v1/artist/`+service.ID, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/weapi/v1/artist/`+service.ID, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
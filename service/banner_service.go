@@ -1,14 +1,23 @@
 package service
 
 import (
+	"context"
+
 	"github.com/go-musicfox/netease-music/util"
 )
 
 type BannerService struct {
 	Type string `json:"type" form:"type"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *BannerService) Banner() (float64, []byte) {
+	code, reBody, _ := service.BannerContext(context.Background())
+	return code, reBody
+}
+
+func (service *BannerService) BannerContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto: "linuxapi",
@@ -28,7 +37,7 @@ func (service *BannerService) Banner() (float64, []byte) {
 	}
 	data["clientType"] = service.Type
 
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/api/v2/banner/get`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/api/v2/banner/get`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
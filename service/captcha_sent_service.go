@@ -1,7 +1,7 @@
 package service
 
 import (
-	"net/http"
+	"context"
 
 	"github.com/go-musicfox/netease-music/util"
 )
@@ -9,15 +9,20 @@ import (
 type CaptchaSentService struct {
 	Ctcode    string `json:"ctcode" form:"ctcode"`
 	Cellphone string `json:"phone" form:"phone"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *CaptchaSentService) CaptchaSent() (float64, []byte) {
+	code, reBody, _ := service.CaptchaSentContext(context.Background())
+	return code, reBody
+}
 
-	cookiesOS := &http.Cookie{Name: "os", Value: "pc"}
+func (service *CaptchaSentService) CaptchaSentContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto:  "weapi",
-		Cookies: []*http.Cookie{cookiesOS},
+		Profile: util.WebProfile(),
 	}
 	data := make(map[string]string)
 	if service.Ctcode == "" {
@@ -27,7 +32,7 @@ func (service *CaptchaSentService) CaptchaSent() (float64, []byte) {
 	}
 	data["cellphone"] = service.Cellphone
 
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/weapi/sms/captcha/sent`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/weapi/sms/captcha/sent`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
@@ -1,7 +1,7 @@
 package service
 
 import (
-	"net/http"
+	"context"
 
 	"github.com/go-musicfox/netease-music/util"
 )
@@ -10,15 +10,20 @@ type CaptchaVerifyService struct {
 	Ctcode    string `json:"ctcode" form:"ctcode"`
 	Cellphone string `json:"phone" form:"phone"`
 	Captcha   string `json:"captcha" form:"captcha"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *CaptchaVerifyService) CaptchaVerify() (float64, []byte) {
+	code, reBody, _ := service.CaptchaVerifyContext(context.Background())
+	return code, reBody
+}
 
-	cookiesOS := &http.Cookie{Name: "os", Value: "pc"}
+func (service *CaptchaVerifyService) CaptchaVerifyContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto:  "weapi",
-		Cookies: []*http.Cookie{cookiesOS},
+		Profile: util.WebProfile(),
 	}
 	data := make(map[string]string)
 	if service.Ctcode == "" {
@@ -29,7 +34,7 @@ func (service *CaptchaVerifyService) CaptchaVerify() (float64, []byte) {
 	data["cellphone"] = service.Cellphone
 	data["captcha"] = service.Captcha
 
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/weapi/sms/captcha/verify`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/weapi/sms/captcha/verify`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
@@ -1,15 +1,24 @@
 package service
 
 import (
+	"context"
+
 	"github.com/go-musicfox/netease-music/util"
 )
 
 type CellphoneExistenceCheckService struct {
 	Cellphone   string `json:"phone" form:"phone"`
 	Countrycode string `json:"countrycode" form:"countrycode"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *CellphoneExistenceCheckService) CellphoneExistenceCheck() (float64, []byte) {
+	code, reBody, _ := service.CellphoneExistenceCheckContext(context.Background())
+	return code, reBody
+}
+
+func (service *CellphoneExistenceCheckService) CellphoneExistenceCheckContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto: "eapi",
@@ -21,7 +30,7 @@ func (service *CellphoneExistenceCheckService) CellphoneExistenceCheck() (float6
 	}
 	data["cellphone"] = service.Cellphone
 
-	code, reBody, _ := util.CreateRequest("POST", `http://music.163.com/eapi/cellphone/existence/check`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `http://music.163.com/eapi/cellphone/existence/check`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
@@ -1,15 +1,24 @@
 package service
 
 import (
+	"context"
+
 	"github.com/go-musicfox/netease-music/util"
 )
 
 type CheckMusicService struct {
 	ID string `json:"id" form:"id"`
 	Br string `json:"br" form:"br"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *CheckMusicService) CheckMusic() (float64, []byte) {
+	code, reBody, _ := service.CheckMusicContext(context.Background())
+	return code, reBody
+}
+
+func (service *CheckMusicService) CheckMusicContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto: "weapi",
@@ -20,7 +29,7 @@ func (service *CheckMusicService) CheckMusic() (float64, []byte) {
 		service.Br = "999000"
 	}
 	data["br"] = service.Br
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/api/song/enhance/player/url`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/api/song/enhance/player/url`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
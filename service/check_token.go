@@ -0,0 +1,73 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/go-musicfox/netease-music/util"
+)
+
+// CheckTokenRequest 获取 checkToken 时的登录信息
+type CheckTokenRequest struct {
+	Client *util.Client
+	// Method 登录方式：cellphone、email
+	Method string
+	// Account 手机号或邮箱
+	Account     string
+	CountryCode string
+}
+
+// CheckTokenProvider 为密码登录前的安全检查（/api/user/login/secure）提供 checkToken
+//
+// checkToken 是网页端行为验证的结果，需要由外部的验证服务生成。返回空字符串时跳过安全检查直接登录
+type CheckTokenProvider interface {
+	CheckToken(ctx context.Context, req *CheckTokenRequest) (string, error)
+}
+
+// CheckTokenFunc 使普通函数满足 CheckTokenProvider
+type CheckTokenFunc func(ctx context.Context, req *CheckTokenRequest) (string, error)
+
+func (f CheckTokenFunc) CheckToken(ctx context.Context, req *CheckTokenRequest) (string, error) {
+	return f(ctx, req)
+}
+
+// NoCheckToken 默认的 CheckTokenProvider，总是返回空字符串，即不做安全检查
+type NoCheckToken struct{}
+
+func (NoCheckToken) CheckToken(context.Context, *CheckTokenRequest) (string, error) {
+	return "", nil
+}
+
+var (
+	checkTokenMu       sync.RWMutex
+	checkTokenProvider CheckTokenProvider = NoCheckToken{}
+)
+
+// SetCheckTokenProvider 设置未指定 CheckToken 的登录 service 使用的 CheckTokenProvider，传入 nil 时恢复为 NoCheckToken
+func SetCheckTokenProvider(p CheckTokenProvider) {
+	if p == nil {
+		p = NoCheckToken{}
+	}
+	checkTokenMu.Lock()
+	defer checkTokenMu.Unlock()
+	checkTokenProvider = p
+}
+
+// loginSecure 按 CheckTokenProvider 获取 checkToken 并进行登录安全检查，checkToken 为空时跳过并返回 0
+func loginSecure(ctx context.Context, p CheckTokenProvider, req *CheckTokenRequest, data map[string]interface{}) (float64, []byte, error) {
+	if p == nil {
+		checkTokenMu.RLock()
+		p = checkTokenProvider
+		checkTokenMu.RUnlock()
+	}
+	token, err := p.CheckToken(ctx, req)
+	if err != nil {
+		return 0, nil, fmt.Errorf("获取 checkToken 失败: %w", err)
+	}
+	if token == "" {
+		return 0, nil, nil
+	}
+	data["checkToken"] = token
+	return req.Client.CallWeapiContext(ctx, "https://music.163.com/api/user/login/secure", data)
+}
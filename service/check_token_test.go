@@ -0,0 +1,61 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-musicfox/netease-music/neteasetest"
+	"github.com/go-musicfox/netease-music/util"
+)
+
+func TestCheckTokenProvider(t *testing.T) {
+	server := neteasetest.NewServer()
+	defer server.Close()
+	server.HandleJSON("/api/user/login/secure", `{"code":200}`)
+	ctx := context.Background()
+
+	// 默认不做安全检查
+	if _, _, err := (&LoginCellphoneService{Client: server.NewClient(), Phone: "13800000000", Password: "pw"}).LoginCellphoneContext(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if server.LastRequest("/api/user/login/secure") != nil {
+		t.Fatal("secure check sent without a provider")
+	}
+
+	var got *CheckTokenRequest
+	provider := CheckTokenFunc(func(ctx context.Context, req *CheckTokenRequest) (string, error) {
+		got = req
+		return "token-" + req.Method, nil
+	})
+	service := &LoginCellphoneService{Client: server.NewClient(), Phone: "13800000000", Password: "pw", CheckToken: provider}
+	if _, _, err := service.LoginCellphoneContext(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if got == nil || got.Account != "13800000000" || got.CountryCode != "86" {
+		t.Fatalf("provider called with %+v", got)
+	}
+	if req := server.LastRequest("/api/user/login/secure"); req == nil || req.Param("checkToken") != "token-cellphone" || req.Param("phone") != "13800000000" {
+		t.Fatalf("secure check: %+v", req)
+	}
+
+	// 全局 provider 同样对邮箱登录生效
+	SetCheckTokenProvider(provider)
+	defer SetCheckTokenProvider(nil)
+	if _, _, err := (&LoginEmailService{Client: server.NewClient(), Email: "a@163.com", Password: "pw"}).LoginEmailContext(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if req := server.LastRequest("/api/user/login/secure"); req.Param("checkToken") != "token-email" || req.Param("username") != "a@163.com" {
+		t.Fatalf("secure check: %v", req.Params)
+	}
+
+	// 安全检查未通过时不再登录
+	server.HandleJSON("/api/user/login/secure", `{"code":8821,"message":"需要行为验证码验证"}`)
+	before := len(server.Requests())
+	if _, _, err := service.LoginCellphoneContext(ctx); !errors.Is(err, util.ErrRiskControl) {
+		t.Fatalf("err %v", err)
+	}
+	if n := len(server.Requests()) - before; n != 1 {
+		t.Fatalf("%d requests after failed secure check", n)
+	}
+}
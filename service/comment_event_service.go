@@ -1,6 +1,8 @@
 package service
 
 import (
+	"context"
+
 	"github.com/go-musicfox/netease-music/util"
 )
 
@@ -9,9 +11,16 @@ type CommentEventService struct {
 	Limit      string `json:"limit" form:"limit"`
 	Offset     string `json:"offset" form:"offset"`
 	BeforeTime string `json:"beforeTime" form:"beforeTime"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *CommentEventService) CommentEvent() (float64, []byte) {
+	code, reBody, _ := service.CommentEventContext(context.Background())
+	return code, reBody
+}
+
+func (service *CommentEventService) CommentEventContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto: "weapi",
@@ -33,7 +42,7 @@ func (service *CommentEventService) CommentEvent() (float64, []byte) {
 		data["beforeTime"] = service.BeforeTime
 	}
 
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/weapi/v1/resource/comments/`+service.ThreadId, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/weapi/v1/resource/comments/`+service.ThreadId, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
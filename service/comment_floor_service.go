@@ -1,6 +1,8 @@
 package service
 
 import (
+	"context"
+
 	"github.com/go-musicfox/netease-music/util"
 )
 
@@ -10,9 +12,16 @@ type CommentFloorService struct {
 	Type            string `json:"type" form:"type"`
 	Id              string `json:"id" form:"id"`
 	Time            string `json:"time" form:"time"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *CommentFloorService) CommentFloor() (float64, []byte) {
+	code, reBody, _ := service.CommentFloorContext(context.Background())
+	return code, reBody
+}
+
+func (service *CommentFloorService) CommentFloorContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto: "weapi",
@@ -38,7 +47,7 @@ func (service *CommentFloorService) CommentFloor() (float64, []byte) {
 	}
 	data["parentCommentId"] = service.ParentCommentId
 	data["threadId"] = Type[service.Type] + service.Id
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/api/resource/comment/floor/get`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/api/resource/comment/floor/get`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
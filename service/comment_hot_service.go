@@ -1,7 +1,7 @@
 package service
 
 import (
-	"net/http"
+	"context"
 
 	"github.com/go-musicfox/netease-music/util"
 )
@@ -12,15 +12,20 @@ type CommentHotService struct {
 	Offset string `json:"offset" form:"offset"`
 	Before string `json:"before" form:"before"`
 	Type   string `json:"type" form:"type"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *CommentHotService) CommentHot() (float64, []byte) {
+	code, reBody, _ := service.CommentHotContext(context.Background())
+	return code, reBody
+}
 
-	cookiesOS := &http.Cookie{Name: "os", Value: "pc"}
+func (service *CommentHotService) CommentHotContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto:  "weapi",
-		Cookies: []*http.Cookie{cookiesOS},
+		Profile: util.WebProfile(),
 	}
 	TYPE := make(map[string]string, 6)
 	TYPE["0"] = "R_SO_4_"
@@ -52,7 +57,7 @@ func (service *CommentHotService) CommentHot() (float64, []byte) {
 	} else {
 		data["beforeTime"] = service.Before
 	}
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/weapi/v1/resource/hotcomments/`+service.Type+service.ID, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/weapi/v1/resource/hotcomments/`+service.Type+service.ID, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
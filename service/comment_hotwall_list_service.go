@@ -1,20 +1,28 @@
 package service
 
 import (
+	"context"
+
 	"github.com/go-musicfox/netease-music/util"
 )
 
 type CommentHotwallListService struct {
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *CommentHotwallListService) CommentHotwallList() (float64, []byte) {
+	code, reBody, _ := service.CommentHotwallListContext(context.Background())
+	return code, reBody
+}
+
+func (service *CommentHotwallListService) CommentHotwallListContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto: "weapi",
 	}
 	data := make(map[string]string)
 
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/api/comment/hotwall/list/get`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/api/comment/hotwall/list/get`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
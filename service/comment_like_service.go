@@ -1,7 +1,7 @@
 package service
 
 import (
-	"net/http"
+	"context"
 
 	"github.com/go-musicfox/netease-music/util"
 )
@@ -12,16 +12,21 @@ type CommentLikeService struct {
 	Cid      string `json:"cid" form:"cid"`
 	T        string `json:"t" form:"t"`
 	Type     string `json:"type" form:"type"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *CommentLikeService) CommentLike() (float64, []byte) {
+	code, reBody, _ := service.CommentLikeContext(context.Background())
+	return code, reBody
+}
 
-	// 获得所有cookie
-	cookiesOS := &http.Cookie{Name: "os", Value: "pc"}
+func (service *CommentLikeService) CommentLikeContext(ctx context.Context) (float64, []byte, error) {
 
+	// 获得所有cookie
 	options := &util.Options{
 		Crypto:  "weapi",
-		Cookies: []*http.Cookie{cookiesOS},
+		Profile: util.WebProfile(),
 	}
 
 	TYPE := make(map[string]string, 6)
@@ -53,7 +58,7 @@ func (service *CommentLikeService) CommentLike() (float64, []byte) {
 		service.T = "unlike"
 	}
 
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/weapi/v1/comment/`+service.T, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/weapi/v1/comment/`+service.T, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
@@ -1,7 +1,7 @@
 package service
 
 import (
-	"net/http"
+	"context"
 
 	"github.com/go-musicfox/netease-music/util"
 )
@@ -11,15 +11,20 @@ type CommentMvService struct {
 	Limit  string `json:"limit" form:"limit"`
 	Offset string `json:"offset" form:"offset"`
 	Before string `json:"before" form:"before"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *CommentMvService) CommentMv() (float64, []byte) {
+	code, reBody, _ := service.CommentMvContext(context.Background())
+	return code, reBody
+}
 
-	cookiesOS := &http.Cookie{Name: "os", Value: "pc"}
+func (service *CommentMvService) CommentMvContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto:  "weapi",
-		Cookies: []*http.Cookie{cookiesOS},
+		Profile: util.WebProfile(),
 	}
 	data := make(map[string]string)
 	data["rid"] = service.ID
@@ -38,7 +43,7 @@ func (service *CommentMvService) CommentMv() (float64, []byte) {
 	} else {
 		data["beforeTime"] = service.Before
 	}
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/weapi/v1/resource/comments/R_MV_5_`+service.ID, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/weapi/v1/resource/comments/R_MV_5_`+service.ID, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
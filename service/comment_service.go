@@ -1,7 +1,7 @@
 package service
 
 import (
-	"net/http"
+	"context"
 
 	"github.com/go-musicfox/netease-music/util"
 )
@@ -13,15 +13,20 @@ type CommentService struct {
 	T         string `json:"t" form:"t"`
 	Type      string `json:"type" form:"type"`
 	CommentId string `json:"commentId" form:"commentId"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *CommentService) Comment() (float64, []byte) {
+	code, reBody, _ := service.CommentContext(context.Background())
+	return code, reBody
+}
 
-	cookiesOS := &http.Cookie{Name: "os", Value: "pc"}
+func (service *CommentService) CommentContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto:  "weapi",
-		Cookies: []*http.Cookie{cookiesOS},
+		Profile: util.WebProfile(),
 	}
 	TYPE := make(map[string]string, 6)
 	TYPE["0"] = "R_SO_4_"
@@ -64,7 +69,7 @@ func (service *CommentService) Comment() (float64, []byte) {
 		data["commentId"] = service.CommentId
 		data["content"] = service.Content
 	}
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/weapi/resource/comments/`+service.T, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/weapi/resource/comments/`+service.T, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
@@ -1,20 +1,28 @@
 package service
 
 import (
+	"context"
+
 	"github.com/go-musicfox/netease-music/util"
 )
 
 type CountriesCodeListService struct {
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *CountriesCodeListService) CountriesCodeList() (float64, []byte) {
+	code, reBody, _ := service.CountriesCodeListContext(context.Background())
+	return code, reBody
+}
+
+func (service *CountriesCodeListService) CountriesCodeListContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto: "eapi",
 		Url:    "/api/lbs/countries/v1",
 	}
 	data := make(map[string]string)
-	code, reBody, _ := util.CreateRequest("POST", `http://interface3.music.163.com/eapi/lbs/countries/v1`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `http://interface3.music.163.com/eapi/lbs/countries/v1`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
@@ -1,14 +1,23 @@
 package service
 
 import (
+	"context"
+
 	"github.com/go-musicfox/netease-music/util"
 )
 
 type DailySigninService struct {
 	Type string `json:"type" form:"type"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *DailySigninService) DailySignin() (float64, []byte) {
+	code, reBody, _ := service.DailySigninContext(context.Background())
+	return code, reBody
+}
+
+func (service *DailySigninService) DailySigninContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto: "weapi",
@@ -21,7 +30,7 @@ func (service *DailySigninService) DailySignin() (float64, []byte) {
 		data["type"] = service.Type
 	}
 
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/weapi/point/dailyTask`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/weapi/point/dailyTask`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
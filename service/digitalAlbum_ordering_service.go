@@ -1,6 +1,7 @@
 package service
 
 import (
+	"context"
 	"encoding/json"
 
 	"github.com/go-musicfox/netease-music/util"
@@ -10,9 +11,16 @@ type DigitalAlbumOrderingService struct {
 	ID            string `json:"id" form:"id"`
 	PaymentMethod string `json:"payment" form:"payment"`
 	Quantity      string `json:"quantity" form:"quantity"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *DigitalAlbumOrderingService) DigitalAlbumOrdering() (float64, []byte) {
+	code, reBody, _ := service.DigitalAlbumOrderingContext(context.Background())
+	return code, reBody
+}
+
+func (service *DigitalAlbumOrderingService) DigitalAlbumOrderingContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto: "weapi",
@@ -32,7 +40,7 @@ func (service *DigitalAlbumOrderingService) DigitalAlbumOrdering() (float64, []b
 
 	data["digitalResources"] = string(dig)
 
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/api/ordering/web/digital`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/api/ordering/web/digital`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
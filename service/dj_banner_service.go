@@ -1,24 +1,28 @@
 package service
 
 import (
-	"net/http"
+	"context"
 
 	"github.com/go-musicfox/netease-music/util"
 )
 
 type DjBannerService struct {
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *DjBannerService) DjBanner() (float64, []byte) {
+	code, reBody, _ := service.DjBannerContext(context.Background())
+	return code, reBody
+}
 
-	cookiesOS := &http.Cookie{Name: "os", Value: "pc"}
+func (service *DjBannerService) DjBannerContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto:  "weapi",
-		Cookies: []*http.Cookie{cookiesOS},
+		Profile: util.WebProfile(),
 	}
 	data := make(map[string]string)
-	code, reBody, _ := util.CreateRequest("POST", `http://music.163.com/weapi/djradio/banner/get`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `http://music.163.com/weapi/djradio/banner/get`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
@@ -1,19 +1,27 @@
 package service
 
 import (
+	"context"
+
 	"github.com/go-musicfox/netease-music/util"
 )
 
 type DjCategoryRecommendService struct {
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *DjCategoryRecommendService) DjCategoryRecommend() (float64, []byte) {
+	code, reBody, _ := service.DjCategoryRecommendContext(context.Background())
+	return code, reBody
+}
+
+func (service *DjCategoryRecommendService) DjCategoryRecommendContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto: "weapi",
 	}
 	data := make(map[string]string)
-	code, reBody, _ := util.CreateRequest("POST", `http://music.163.com/weapi/djradio/home/category/recommend`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `http://music.163.com/weapi/djradio/home/category/recommend`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
@@ -1,19 +1,27 @@
 package service
 
 import (
+	"context"
+
 	"github.com/go-musicfox/netease-music/util"
 )
 
 type DjCatelistService struct {
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *DjCatelistService) DjCatelist() (float64, []byte) {
+	code, reBody, _ := service.DjCatelistContext(context.Background())
+	return code, reBody
+}
+
+func (service *DjCatelistService) DjCatelistContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto: "weapi",
 	}
 	data := make(map[string]string)
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/weapi/djradio/category/get`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/weapi/djradio/category/get`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
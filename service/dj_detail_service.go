@@ -1,21 +1,30 @@
 package service
 
 import (
+	"context"
+
 	"github.com/go-musicfox/netease-music/util"
 )
 
 type DjDetailService struct {
 	ID string `json:"rid" form:"rid"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *DjDetailService) DjDetail() (float64, []byte) {
+	code, reBody, _ := service.DjDetailContext(context.Background())
+	return code, reBody
+}
+
+func (service *DjDetailService) DjDetailContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto: "weapi",
 	}
 	data := make(map[string]string)
 	data["id"] = service.ID
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/weapi/djradio/get`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/weapi/djradio/get`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
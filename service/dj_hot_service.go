@@ -1,15 +1,24 @@
 package service
 
 import (
+	"context"
+
 	"github.com/go-musicfox/netease-music/util"
 )
 
 type DjHotService struct {
 	Limit  string `json:"limit" form:"limit"`
 	Offset string `json:"offset" form:"offset"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *DjHotService) DjHot() (float64, []byte) {
+	code, reBody, _ := service.DjHotContext(context.Background())
+	return code, reBody
+}
+
+func (service *DjHotService) DjHotContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto: "weapi",
@@ -27,7 +36,7 @@ func (service *DjHotService) DjHot() (float64, []byte) {
 
 		data["offset"] = service.Offset
 	}
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/weapi/djradio/hot/v1`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/weapi/djradio/hot/v1`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
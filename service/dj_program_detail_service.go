@@ -1,21 +1,30 @@
 package service
 
 import (
+	"context"
+
 	"github.com/go-musicfox/netease-music/util"
 )
 
 type DjProgramDetailService struct {
 	ID string `json:"id" form:"id"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *DjProgramDetailService) DjProgramDetail() (float64, []byte) {
+	code, reBody, _ := service.DjProgramDetailContext(context.Background())
+	return code, reBody
+}
+
+func (service *DjProgramDetailService) DjProgramDetailContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto: "weapi",
 	}
 	data := make(map[string]string)
 	data["id"] = service.ID
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/weapi/dj/program/detail`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/weapi/dj/program/detail`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
@@ -1,6 +1,8 @@
 package service
 
 import (
+	"context"
+
 	"github.com/go-musicfox/netease-music/util"
 )
 
@@ -9,9 +11,16 @@ type DjProgramService struct {
 	Limit  string `json:"limit" form:"limit"`
 	Offset string `json:"offset" form:"offset"`
 	Asc    string `json:"asc" form:"asc"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *DjProgramService) DjProgram() (float64, []byte) {
+	code, reBody, _ := service.DjProgramContext(context.Background())
+	return code, reBody
+}
+
+func (service *DjProgramService) DjProgramContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto: "weapi",
@@ -29,7 +38,7 @@ func (service *DjProgramService) DjProgram() (float64, []byte) {
 		data["offset"] = service.Offset
 	}
 	data["asc"] = service.Asc
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/weapi/dj/program/byradio`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/weapi/dj/program/byradio`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
@@ -1,14 +1,23 @@
 package service
 
 import (
+	"context"
+
 	"github.com/go-musicfox/netease-music/util"
 )
 
 type DjProgramToplistHoursService struct {
 	Limit string `json:"limit" form:"limit"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *DjProgramToplistHoursService) DjProgramToplistHours() (float64, []byte) {
+	code, reBody, _ := service.DjProgramToplistHoursContext(context.Background())
+	return code, reBody
+}
+
+func (service *DjProgramToplistHoursService) DjProgramToplistHoursContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto: "weapi",
@@ -19,7 +28,7 @@ func (service *DjProgramToplistHoursService) DjProgramToplistHours() (float64, [
 	} else {
 		data["limit"] = service.Limit
 	}
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/api/djprogram/toplist/hours`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/api/djprogram/toplist/hours`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
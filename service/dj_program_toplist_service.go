@@ -1,15 +1,24 @@
 package service
 
 import (
+	"context"
+
 	"github.com/go-musicfox/netease-music/util"
 )
 
 type DjProgramToplistService struct {
 	Limit  string `json:"limit" form:"limit"`
 	Offset string `json:"offset" form:"offset"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *DjProgramToplistService) DjProgramToplist() (float64, []byte) {
+	code, reBody, _ := service.DjProgramToplistContext(context.Background())
+	return code, reBody
+}
+
+func (service *DjProgramToplistService) DjProgramToplistContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto: "weapi",
@@ -25,7 +34,7 @@ func (service *DjProgramToplistService) DjProgramToplist() (float64, []byte) {
 	} else {
 		data["offset"] = service.Offset
 	}
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/api/program/toplist/v1`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/api/program/toplist/v1`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
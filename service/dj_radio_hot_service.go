@@ -1,6 +1,8 @@
 package service
 
 import (
+	"context"
+
 	"github.com/go-musicfox/netease-music/util"
 )
 
@@ -8,9 +10,16 @@ type DjRadioHotService struct {
 	CateId string `json:"cateId" form:"cateId"`
 	Limit  string `json:"limit" form:"limit"`
 	Offset string `json:"offset" form:"offset"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *DjRadioHotService) DjRadioHot() (float64, []byte) {
+	code, reBody, _ := service.DjRadioHotContext(context.Background())
+	return code, reBody
+}
+
+func (service *DjRadioHotService) DjRadioHotContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto: "weapi",
@@ -27,7 +36,7 @@ func (service *DjRadioHotService) DjRadioHot() (float64, []byte) {
 	} else {
 		data["offset"] = service.Offset
 	}
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/api/djradio/hot`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/api/djradio/hot`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
@@ -1,20 +1,28 @@
 package service
 
 import (
+	"context"
+
 	"github.com/go-musicfox/netease-music/util"
 )
 
 type DjRecommendService struct {
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *DjRecommendService) DjRecommend() (float64, []byte) {
+	code, reBody, _ := service.DjRecommendContext(context.Background())
+	return code, reBody
+}
+
+func (service *DjRecommendService) DjRecommendContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto: "weapi",
 	}
 	data := make(map[string]string)
 
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/weapi/djradio/recommend/v1`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/weapi/djradio/recommend/v1`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
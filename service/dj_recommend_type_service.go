@@ -1,6 +1,8 @@
 package service
 
 import (
+	"context"
+
 	"github.com/go-musicfox/netease-music/util"
 )
 
@@ -29,16 +31,23 @@ import (
 
 type DjRecommendTypeService struct {
 	CateId string `json:"type" form:"type"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *DjRecommendTypeService) DjRecommendType() (float64, []byte) {
+	code, reBody, _ := service.DjRecommendTypeContext(context.Background())
+	return code, reBody
+}
+
+func (service *DjRecommendTypeService) DjRecommendTypeContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto: "weapi",
 	}
 	data := make(map[string]string)
 	data["cateId"] = service.CateId
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/weapi/djradio/recommend`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/weapi/djradio/recommend`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
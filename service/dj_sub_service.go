@@ -1,15 +1,24 @@
 package service
 
 import (
+	"context"
+
 	"github.com/go-musicfox/netease-music/util"
 )
 
 type DjSubService struct {
 	RID string `json:"rid" form:"rid"`
 	T   string `json:"t" form:"t"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *DjSubService) DjSub() (float64, []byte) {
+	code, reBody, _ := service.DjSubContext(context.Background())
+	return code, reBody
+}
+
+func (service *DjSubService) DjSubContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto: "weapi",
@@ -21,7 +30,7 @@ func (service *DjSubService) DjSub() (float64, []byte) {
 	} else {
 		service.T = "unsub"
 	}
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/weapi/djradio/`+service.T, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/weapi/djradio/`+service.T, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
@@ -1,14 +1,23 @@
 package service
 
 import (
+	"context"
+
 	"github.com/go-musicfox/netease-music/util"
 )
 
 type DjTodayPerferedService struct {
 	Page string `json:"page" form:"page"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *DjTodayPerferedService) DjTodayPerfered() (float64, []byte) {
+	code, reBody, _ := service.DjTodayPerferedContext(context.Background())
+	return code, reBody
+}
+
+func (service *DjTodayPerferedService) DjTodayPerferedContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto: "weapi",
@@ -19,7 +28,7 @@ func (service *DjTodayPerferedService) DjTodayPerfered() (float64, []byte) {
 	} else {
 		data["page"] = service.Page
 	}
-	code, reBody, _ := util.CreateRequest("POST", `http://music.163.com/weapi/djradio/home/today/perfered`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `http://music.163.com/weapi/djradio/home/today/perfered`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
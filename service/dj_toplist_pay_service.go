@@ -1,14 +1,23 @@
 package service
 
 import (
+	"context"
+
 	"github.com/go-musicfox/netease-music/util"
 )
 
 type DjToplistPayService struct {
 	Limit string `json:"limit" form:"limit"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *DjToplistPayService) DjToplistPay() (float64, []byte) {
+	code, reBody, _ := service.DjToplistPayContext(context.Background())
+	return code, reBody
+}
+
+func (service *DjToplistPayService) DjToplistPayContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto: "weapi",
@@ -21,7 +30,7 @@ func (service *DjToplistPayService) DjToplistPay() (float64, []byte) {
 		data["limit"] = service.Limit
 	}
 
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/api/djradio/toplist/pay`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/api/djradio/toplist/pay`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
@@ -1,14 +1,23 @@
 package service
 
 import (
+	"context"
+
 	"github.com/go-musicfox/netease-music/util"
 )
 
 type DjToplistPopularService struct {
 	Limit string `json:"limit" form:"limit"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *DjToplistPopularService) DjToplistPopular() (float64, []byte) {
+	code, reBody, _ := service.DjToplistPopularContext(context.Background())
+	return code, reBody
+}
+
+func (service *DjToplistPopularService) DjToplistPopularContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto: "weapi",
@@ -20,7 +29,7 @@ func (service *DjToplistPopularService) DjToplistPopular() (float64, []byte) {
 		data["limit"] = service.Limit
 	}
 
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/api/dj/toplist/popular`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/api/dj/toplist/popular`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
@@ -1,6 +1,8 @@
 package service
 
 import (
+	"context"
+
 	"github.com/go-musicfox/netease-music/util"
 )
 
@@ -8,9 +10,16 @@ type DjToplistService struct {
 	Type   string `json:"type" form:"type"`
 	Limit  string `json:"limit" form:"limit"`
 	Offset string `json:"offset" form:"offset"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *DjToplistService) DjToplist() (float64, []byte) {
+	code, reBody, _ := service.DjToplistContext(context.Background())
+	return code, reBody
+}
+
+func (service *DjToplistService) DjToplistContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto: "weapi",
@@ -31,7 +40,7 @@ func (service *DjToplistService) DjToplist() (float64, []byte) {
 	} else {
 		data["type"] = "0"
 	}
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/api/djradio/toplist`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/api/djradio/toplist`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
@@ -1,14 +1,23 @@
 package service
 
 import (
+	"context"
+
 	"github.com/go-musicfox/netease-music/util"
 )
 
 type DjToplistHoursService struct {
 	Limit string `json:"limit" form:"limit"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *DjToplistHoursService) DjToplistHours() (float64, []byte) {
+	code, reBody, _ := service.DjToplistHoursContext(context.Background())
+	return code, reBody
+}
+
+func (service *DjToplistHoursService) DjToplistHoursContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto: "weapi",
@@ -19,7 +28,7 @@ func (service *DjToplistHoursService) DjToplistHours() (float64, []byte) {
 	} else {
 		data["limit"] = service.Limit
 	}
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/api/dj/toplist/hours`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/api/dj/toplist/hours`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
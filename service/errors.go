@@ -0,0 +1,24 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/go-musicfox/netease-music/util"
+)
+
+// PhoneLogin 在发出请求前返回的错误
+var (
+	ErrInvalidCountryCode = errors.New("netease: 国家码格式错误")
+	ErrInvalidPhone       = errors.New("netease: 手机号格式错误")
+	ErrCaptchaCooldown    = errors.New("netease: 验证码发送过于频繁")
+	ErrPhoneNotRegistered = errors.New("netease: 手机号未注册")
+)
+
+// legacyError 保持旧版方法的约定：只有请求本身失败时才返回 error，业务状态码仅通过 code 返回
+func legacyError(err error) error {
+	var apiErr *util.APIError
+	if errors.As(err, &apiErr) {
+		return nil
+	}
+	return err
+}
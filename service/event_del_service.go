@@ -1,27 +1,32 @@
 package service
 
 import (
-	"net/http"
+	"context"
 
 	"github.com/go-musicfox/netease-music/util"
 )
 
 type EventDelService struct {
 	EvId string `json:"evId" form:"evId"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *EventDelService) EventDel() (float64, []byte) {
+	code, reBody, _ := service.EventDelContext(context.Background())
+	return code, reBody
+}
 
-	cookiesOS := &http.Cookie{Name: "os", Value: "pc"}
+func (service *EventDelService) EventDelContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto:  "weapi",
-		Cookies: []*http.Cookie{cookiesOS},
+		Profile: util.WebProfile(),
 	}
 	data := make(map[string]string)
 	data["id"] = service.EvId
 
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/eapi/event/delete`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/eapi/event/delete`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
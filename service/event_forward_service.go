@@ -1,7 +1,7 @@
 package service
 
 import (
-	"net/http"
+	"context"
 
 	"github.com/go-musicfox/netease-music/util"
 )
@@ -10,21 +10,26 @@ type EventForwardService struct {
 	Uid      string `json:"uid" form:"uid"`
 	EvId     string `json:"evId" form:"evId"`
 	Forwards string `json:"forwards" form:"forwards"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *EventForwardService) EventForward() (float64, []byte) {
+	code, reBody, _ := service.EventForwardContext(context.Background())
+	return code, reBody
+}
 
-	cookiesOS := &http.Cookie{Name: "os", Value: "pc"}
+func (service *EventForwardService) EventForwardContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto:  "weapi",
-		Cookies: []*http.Cookie{cookiesOS},
+		Profile: util.WebProfile(),
 	}
 	data := make(map[string]string)
 	data["id"] = service.EvId
 	data["eventUserId"] = service.Uid
 	data["forwards"] = service.Forwards
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/weapi/event/forward`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/weapi/event/forward`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
@@ -1,15 +1,24 @@
 package service
 
 import (
+	"context"
+
 	"github.com/go-musicfox/netease-music/util"
 )
 
 type EventService struct {
 	PageSize string `json:"pagesize" form:"pagesize"`
 	LastTime string `json:"lasttime" form:"lasttime"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *EventService) Event() (float64, []byte) {
+	code, reBody, _ := service.EventContext(context.Background())
+	return code, reBody
+}
+
+func (service *EventService) EventContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto: "weapi",
@@ -26,7 +35,7 @@ func (service *EventService) Event() (float64, []byte) {
 		data["lasttime"] = service.LastTime
 	}
 
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/weapi/v1/event/get`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/weapi/v1/event/get`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
@@ -1,14 +1,23 @@
 package service
 
 import (
+	"context"
+
 	"github.com/go-musicfox/netease-music/util"
 )
 
 type FmTrashService struct {
 	SongID string `json:"id" form:"id"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *FmTrashService) FmTrash() (float64, []byte) {
+	code, reBody, _ := service.FmTrashContext(context.Background())
+	return code, reBody
+}
+
+func (service *FmTrashService) FmTrashContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto: "weapi",
@@ -16,7 +25,7 @@ func (service *FmTrashService) FmTrash() (float64, []byte) {
 	data := make(map[string]string)
 	data["songId"] = service.SongID
 
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/weapi/radio/trash/add?alg=RT&songId=`+service.SongID+`&time=25`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/weapi/radio/trash/add?alg=RT&songId=`+service.SongID+`&time=25`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
@@ -1,7 +1,7 @@
 package service
 
 import (
-	"net/http"
+	"context"
 
 	"github.com/go-musicfox/netease-music/util"
 )
@@ -9,15 +9,20 @@ import (
 type FollowService struct {
 	T  string `json:"t" form:"t"`
 	Id string `json:"id" form:"id"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *FollowService) Follow() (float64, []byte) {
+	code, reBody, _ := service.FollowContext(context.Background())
+	return code, reBody
+}
 
-	cookiesOS := &http.Cookie{Name: "os", Value: "pc"}
+func (service *FollowService) FollowContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto:  "weapi",
-		Cookies: []*http.Cookie{cookiesOS},
+		Profile: util.WebProfile(),
 	}
 	data := make(map[string]string)
 	if service.T == "1" {
@@ -26,7 +31,7 @@ func (service *FollowService) Follow() (float64, []byte) {
 		service.T = "delfollow"
 	}
 
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/weapi/user/`+service.T+`/`+service.Id, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/weapi/user/`+service.T+`/`+service.Id, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
@@ -0,0 +1,81 @@
+package service
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/buger/jsonparser"
+	"github.com/go-musicfox/netease-music/util"
+)
+
+// Guest 游客身份
+//
+// 未登录时通过游客注册（RegisterAnonimousService）获取游客凭证 MUSIC_A 并保存在 Client 的 CookieJar 中，
+// CreateRequest 发出的 eapi 请求会携带它，歌曲播放地址、私人FM等接口对游客的结果更好。
+// MUSIC_A 即将过期时会重新注册，已登录的 Client 不会注册游客
+type Guest struct {
+	Client *util.Client
+	// RefreshBefore MUSIC_A 剩余有效期不足该时长时重新注册，默认 1 小时
+	RefreshBefore time.Duration
+
+	mu sync.Mutex
+}
+
+// Ensure 未登录且没有有效的 MUSIC_A 时进行游客注册，否则不发出请求
+func (g *Guest) Ensure(ctx context.Context) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if !g.needsRegister() {
+		return nil
+	}
+	_, err := g.register(ctx)
+	return err
+}
+
+// Register 立即进行游客注册，返回游客的用户ID
+func (g *Guest) Register(ctx context.Context) (int64, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.register(ctx)
+}
+
+func (g *Guest) register(ctx context.Context) (int64, error) {
+	_, body, err := (&RegisterAnonimousService{Client: g.Client}).RegisterAnonimousContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	userID, _ := jsonparser.GetInt(body, "userId")
+	return userID, nil
+}
+
+// needsRegister 调用方需持有 g.mu
+func (g *Guest) needsRegister() bool {
+	u, _ := url.Parse("https://" + util.HostMusic)
+	cookies := g.Client.CookieJar().Cookies(u)
+	if util.CookieValueByName(cookies, "MUSIC_U", "") != "" {
+		return false
+	}
+	if util.CookieValueByName(cookies, "MUSIC_A", "") == "" {
+		return true
+	}
+	before := g.RefreshBefore
+	if before <= 0 {
+		before = time.Hour
+	}
+	expires := g.Client.GuestExpires()
+	return !expires.IsZero() && time.Now().Add(before).After(expires)
+}
+
+// Interceptor 返回在每次请求前调用 Ensure 的拦截器，加入 Client.Interceptors 后未登录的请求会自动使用游客身份。
+// 游客注册失败时请求照常发出
+func (g *Guest) Interceptor() util.Interceptor {
+	return func(ctx context.Context, call *util.Call, next util.Invoker) (*util.Reply, error) {
+		if !strings.Contains(call.URL, "/register/anonimous") {
+			_ = g.Ensure(ctx)
+		}
+		return next(ctx, call)
+	}
+}
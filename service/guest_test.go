@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-musicfox/netease-music/neteasetest"
+	"github.com/go-musicfox/netease-music/util"
+)
+
+func TestGuest(t *testing.T) {
+	server := neteasetest.NewServer()
+	defer server.Close()
+	client := server.NewClient()
+	guest := &Guest{Client: client}
+	client.Interceptors = []util.Interceptor{guest.Interceptor()}
+
+	ctx := context.Background()
+	if _, _, err := (&SongUrlV1Service{Client: client, ID: "405998841"}).SongUrlContext(ctx); err != nil {
+		t.Fatal(err)
+	}
+	reg := server.LastRequest("/api/register/anonimous")
+	if reg == nil || reg.Param("username") != util.AnonymousUsername(client.DeviceId()) {
+		t.Fatalf("register request: %+v", reg)
+	}
+	if req := server.LastRequest("/api/song/enhance/player/url/v1"); req == nil || req.Cookie("MUSIC_A") != neteasetest.TestMusicA {
+		t.Fatalf("MUSIC_A not sent: %+v", req)
+	}
+	server.HandleJSON("/api/homepage/dragon/ball/static", `{"code":200,"data":{}}`)
+	if _, _, err := (&HomepageDragonBallService{Client: client}).HomepageDragonBallContext(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if req := server.LastRequest("/api/homepage/dragon/ball/static"); req == nil || req.Header["MUSIC_A"] != neteasetest.TestMusicA {
+		t.Fatalf("MUSIC_A not in eapi header: %+v", req)
+	}
+	if expires := client.GuestExpires(); expires.Before(time.Now().Add(14 * 24 * time.Hour)) {
+		t.Fatalf("guest expiry not recorded: %v", expires)
+	}
+
+	// MUSIC_A 仍然有效时不再注册
+	count := func() (n int) {
+		for _, r := range server.Requests() {
+			if r.Path == "/api/register/anonimous" {
+				n++
+			}
+		}
+		return n
+	}
+	_ = guest.Ensure(ctx)
+	if n := count(); n != 1 {
+		t.Fatalf("registered %d times", n)
+	}
+
+	// 即将过期时重新注册
+	guest.RefreshBefore = 30 * 24 * time.Hour
+	if err := guest.Ensure(ctx); err != nil || count() != 2 {
+		t.Fatalf("not refreshed: %v, %d", err, count())
+	}
+
+	// 已登录时不注册
+	server.Login(client)
+	guest.RefreshBefore = 0
+	s := client.ExportSession()
+	s.GuestExpiresAt = time.Now()
+	client.ImportSession(s)
+	if err := guest.Ensure(ctx); err != nil || count() != 2 {
+		t.Fatalf("registered while logged in: %v, %d", err, count())
+	}
+}
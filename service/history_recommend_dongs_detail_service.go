@@ -1,27 +1,32 @@
 package service
 
 import (
-	"net/http"
+	"context"
 
 	"github.com/go-musicfox/netease-music/util"
 )
 
 type HistoryRecommendDongsDetailService struct {
 	Date string `json:"date" form:"date"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *HistoryRecommendDongsDetailService) HistoryRecommendDongsDetail() (float64, []byte) {
+	code, reBody, _ := service.HistoryRecommendDongsDetailContext(context.Background())
+	return code, reBody
+}
 
-	cookiesOS := &http.Cookie{Name: "os", Value: "ios"}
+func (service *HistoryRecommendDongsDetailService) HistoryRecommendDongsDetailContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto:  "weapi",
-		Cookies: []*http.Cookie{cookiesOS},
+		Profile: util.IOSProfile(),
 	}
 	data := make(map[string]string)
 	data["date"] = service.Date
 
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/api/discovery/recommend/songs/history/detail`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/api/discovery/recommend/songs/history/detail`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
@@ -1,24 +1,28 @@
 package service
 
 import (
-	"net/http"
+	"context"
 
 	"github.com/go-musicfox/netease-music/util"
 )
 
 type HistoryRecommendSongsService struct {
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *HistoryRecommendSongsService) HistoryRecommendSongs() (float64, []byte) {
+	code, reBody, _ := service.HistoryRecommendSongsContext(context.Background())
+	return code, reBody
+}
 
-	cookiesOS := &http.Cookie{Name: "os", Value: "ios"}
+func (service *HistoryRecommendSongsService) HistoryRecommendSongsContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto:  "weapi",
-		Cookies: []*http.Cookie{cookiesOS},
+		Profile: util.IOSProfile(),
 	}
 	data := make(map[string]string)
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/api/discovery/recommend/songs/history/recent`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/api/discovery/recommend/songs/history/recent`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
@@ -1,29 +1,34 @@
 package service
 
 import (
-	"net/http"
+	"context"
 
 	"github.com/go-musicfox/netease-music/util"
 )
 
 type HomepageBlockPageService struct {
 	Refresh string `json:"refresh" form:"refresh"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *HomepageBlockPageService) HomepageBlockPage() (float64, []byte) {
+	code, reBody, _ := service.HomepageBlockPageContext(context.Background())
+	return code, reBody
+}
 
-	cookiesOS := &http.Cookie{Name: "os", Value: "pc"}
+func (service *HomepageBlockPageService) HomepageBlockPageContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto:  "weapi",
-		Cookies: []*http.Cookie{cookiesOS},
+		Profile: util.WebProfile(),
 	}
 	data := make(map[string]string)
 	if service.Refresh == "" {
 		service.Refresh = "true"
 	}
 	data["refresh"] = service.Refresh
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/api/homepage/block/page`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/api/homepage/block/page`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
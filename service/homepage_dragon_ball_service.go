@@ -1,20 +1,28 @@
 package service
 
 import (
+	"context"
+
 	"github.com/go-musicfox/netease-music/util"
 )
 
 type HomepageDragonBallService struct {
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *HomepageDragonBallService) HomepageDragonBall() (float64, []byte) {
+	code, reBody, _ := service.HomepageDragonBallContext(context.Background())
+	return code, reBody
+}
+
+func (service *HomepageDragonBallService) HomepageDragonBallContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto: "eapi",
 		Url:    "/api/homepage/dragon/ball/static",
 	}
 	data := make(map[string]string)
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/eapi/homepage/dragon/ball/static`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/eapi/homepage/dragon/ball/static`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
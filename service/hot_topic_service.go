@@ -1,15 +1,24 @@
 package service
 
 import (
+	"context"
+
 	"github.com/go-musicfox/netease-music/util"
 )
 
 type HotTopicService struct {
 	Limit  string `json:"limit" form:"limit"`
 	Offset string `json:"offset" form:"offset"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *HotTopicService) HotTopic() (float64, []byte) {
+	code, reBody, _ := service.HotTopicContext(context.Background())
+	return code, reBody
+}
+
+func (service *HotTopicService) HotTopicContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto: "weapi",
@@ -26,7 +35,7 @@ func (service *HotTopicService) HotTopic() (float64, []byte) {
 		data["offset"] = service.Offset
 	}
 	data["order"] = "true"
-	code, reBody, _ := util.CreateRequest("POST", `http://music.163.com/weapi/act/hot`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `http://music.163.com/weapi/act/hot`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
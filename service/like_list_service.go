@@ -1,14 +1,23 @@
 package service
 
 import (
+	"context"
+
 	"github.com/go-musicfox/netease-music/util"
 )
 
 type LikeListService struct {
 	UID string `json:"uid" form:"uid"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *LikeListService) LikeList() (float64, []byte) {
+	code, reBody, _ := service.LikeListContext(context.Background())
+	return code, reBody
+}
+
+func (service *LikeListService) LikeListContext(ctx context.Context) (float64, []byte, error) {
 	options := &util.Options{
 		Crypto: "weapi",
 	}
@@ -16,7 +25,7 @@ func (service *LikeListService) LikeList() (float64, []byte) {
 	data := make(map[string]string)
 	data["uid"] = service.UID
 
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/weapi/song/like/get`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/weapi/song/like/get`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
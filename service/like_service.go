@@ -1,7 +1,7 @@
 package service
 
 import (
-	"net/http"
+	"context"
 
 	"github.com/go-musicfox/netease-music/util"
 )
@@ -9,15 +9,19 @@ import (
 type LikeService struct {
 	ID string `json:"id" form:"id"`
 	L  string `json:"like" form:"like"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *LikeService) Like() (float64, []byte) {
+	code, reBody, _ := service.LikeContext(context.Background())
+	return code, reBody
+}
+
+func (service *LikeService) LikeContext(ctx context.Context) (float64, []byte, error) {
 	options := &util.Options{
-		Crypto: "weapi",
-		Cookies: []*http.Cookie{
-			{Name: "os", Value: "pc"},
-			{Name: "appver", Value: "2.7.1.198277"},
-		},
+		Crypto:  "weapi",
+		Profile: util.LegacyPCProfile(),
 	}
 	data := make(map[string]string)
 	data["trackId"] = service.ID
@@ -29,7 +33,7 @@ func (service *LikeService) Like() (float64, []byte) {
 		data["like"] = service.L
 	}
 
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/api/radio/like`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/api/radio/like`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
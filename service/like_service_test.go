@@ -0,0 +1,28 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/go-musicfox/netease-music/neteasetest"
+)
+
+func TestLikeService_Like(t *testing.T) {
+	server := neteasetest.NewServer()
+	defer server.Close()
+	server.HandleJSON("/api/radio/like", `{"code":200,"playlistId":1}`)
+
+	service := &LikeService{Client: server.NewClient(), ID: "405998841"}
+	if code, resp := service.Like(); code != 200 {
+		t.Fatalf("code error: %f, %s", code, resp)
+	}
+	req := server.LastRequest("/api/radio/like")
+	if req == nil {
+		t.Fatal("request not recorded")
+	}
+	if os, appver := req.Cookie("os"), req.Cookie("appver"); os != "pc" || appver != "2.7.1.198277" {
+		t.Errorf("os %q, appver %q", os, appver)
+	}
+	if req.Param("trackId") != "405998841" || req.Param("like") != "true" {
+		t.Errorf("unexpected params: %v", req.Params)
+	}
+}
@@ -1,6 +1,7 @@
 package service
 
 import (
+	"context"
 	"crypto/md5"
 	"encoding/hex"
 
@@ -14,6 +15,10 @@ type LoginCellphoneService struct {
 	Md5password string `json:"md5_password" form:"md5_password"`
 	Captcha     string `json:"captcha" from:"captcha"`
 	CsrfToken   string `json:"csrf_token" from:"csrf_token"`
+	// CheckToken 密码登录前安全检查使用的 CheckTokenProvider，为 nil 时使用 SetCheckTokenProvider 设置的全局值
+	CheckToken CheckTokenProvider `json:"-" form:"-"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 // LoginCellphone 使用手机号和密码登录
@@ -23,6 +28,14 @@ type LoginCellphoneService struct {
 //   - bodyBytes：返回的响应体
 //   - err：错误内容
 func (service *LoginCellphoneService) LoginCellphone() (float64, []byte, error) {
+	code, bodyBytes, err := service.LoginCellphoneContext(context.Background())
+	return code, bodyBytes, legacyError(err)
+}
+
+// LoginCellphoneContext 使用手机号和密码或验证码登录
+//
+// 使用密码登录且 CheckTokenProvider 返回了 checkToken 时，会先进行登录安全检查
+func (service *LoginCellphoneService) LoginCellphoneContext(ctx context.Context) (float64, []byte, error) {
 	data := make(map[string]interface{})
 
 	data["phone"] = service.Phone
@@ -34,6 +47,12 @@ func (service *LoginCellphoneService) LoginCellphone() (float64, []byte, error)
 
 	if service.Captcha != "" {
 		data["captcha"] = service.Captcha
+	} else {
+		req := &CheckTokenRequest{Client: service.Client, Method: "cellphone", Account: service.Phone, CountryCode: data["countrycode"].(string)}
+		secure := map[string]interface{}{"phone": data["phone"], "countrycode": data["countrycode"]}
+		if code, body, err := loginSecure(ctx, service.CheckToken, req, secure); err != nil {
+			return code, body, err
+		}
 	}
 
 	data["csrf_token"] = service.CsrfToken
@@ -48,21 +67,6 @@ func (service *LoginCellphoneService) LoginCellphone() (float64, []byte, error)
 	data["rememberLogin"] = "true"
 
 	api := "https://music.163.com/weapi/login/cellphone"
-	code, bodyBytes, err := util.CallWeapi(api, data)
-	return code, bodyBytes, err
-}
-
-// web端登录安全检查,需要获取checkToken的值
-func (service *LoginCellphoneService) loginSecure() (float64, []byte, error) {
-	data := make(map[string]interface{})
-	data["phone"] = service.Phone
-	if service.Countrycode != "" {
-		data["countrycode"] = service.Countrycode
-	} else {
-		data["countrycode"] = "86"
-	}
-	data["checkToken"] = "" // 需要动态生成
-	api := "https://music.163.com/api/user/login/secure"
-	code, bodyBytes, err := util.CallWeapi(api, data)
+	code, bodyBytes, err := service.Client.CallWeapiContext(ctx, api, data)
 	return code, bodyBytes, err
 }
@@ -2,10 +2,16 @@ package service
 
 import (
 	"testing"
+
+	"github.com/go-musicfox/netease-music/neteasetest"
 )
 
 func TestLoginCellphoneService_LoginCellphone(t *testing.T) {
+	server := neteasetest.NewServer()
+	defer server.Close()
+
 	service := &LoginCellphoneService{
+		Client:   server.NewClient(),
 		Phone:    "",
 		Password: "",
 	}
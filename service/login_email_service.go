@@ -1,9 +1,9 @@
 package service
 
 import (
+	"context"
 	"crypto/md5"
 	"encoding/hex"
-	"net/http"
 
 	"github.com/go-musicfox/netease-music/util"
 )
@@ -12,16 +12,28 @@ type LoginEmailService struct {
 	Email       string `json:"email" form:"email"`
 	Password    string `json:"password" form:"password"`
 	Md5password string `json:"md5_password" form:"md5_password"`
+	// CheckToken 登录前安全检查使用的 CheckTokenProvider，为 nil 时使用 SetCheckTokenProvider 设置的全局值
+	CheckToken CheckTokenProvider `json:"-" form:"-"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *LoginEmailService) LoginEmail() (float64, []byte) {
+	code, reBody, _ := service.LoginEmailContext(context.Background())
+	return code, reBody
+}
+
+// LoginEmailContext 使用邮箱和密码登录，CheckTokenProvider 返回了 checkToken 时会先进行登录安全检查
+func (service *LoginEmailService) LoginEmailContext(ctx context.Context) (float64, []byte, error) {
+	req := &CheckTokenRequest{Client: service.Client, Method: "email", Account: service.Email}
+	if code, body, err := loginSecure(ctx, service.CheckToken, req, map[string]interface{}{"username": service.Email}); err != nil {
+		return code, body, err
+	}
+
 	options := &util.Options{
-		Crypto: "weapi",
-		Ua:     "pc",
-		Cookies: []*http.Cookie{
-			{Name: "os", Value: "ios"},
-			{Name: "appver", Value: "8.7.01"},
-		},
+		Crypto:  "weapi",
+		Ua:      "pc",
+		Profile: util.LegacyIOSProfile(),
 	}
 	data := make(map[string]string)
 
@@ -35,8 +47,8 @@ func (service *LoginEmailService) LoginEmail() (float64, []byte) {
 	}
 	data["rememberLogin"] = "true"
 
-	//reBody, cookies := util.CreateRequest("POST", `https://www.httpbin.org/post`, data, options)
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/api/login`, data, options)
+	//reBody, cookies := service.Client.CreateRequest("POST", `https://www.httpbin.org/post`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/api/login`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
@@ -3,10 +3,16 @@ package service
 import (
 	"fmt"
 	"testing"
+
+	"github.com/go-musicfox/netease-music/neteasetest"
 )
 
 func TestLoginEmailService_LoginCellphone(t *testing.T) {
+	server := neteasetest.NewServer()
+	defer server.Close()
+
 	service := &LoginEmailService{
+		Client:   server.NewClient(),
 		Email:    "",
 		Password: "",
 	}
@@ -15,4 +21,11 @@ func TestLoginEmailService_LoginCellphone(t *testing.T) {
 	if code != 200 {
 		t.Errorf("code error: %f", code)
 	}
+	req := server.LastRequest("/api/login")
+	if req == nil {
+		t.Fatal("request not recorded")
+	}
+	if os, appver := req.Cookie("os"), req.Cookie("appver"); os != "ios" || appver != "8.7.01" {
+		t.Errorf("os %q, appver %q", os, appver)
+	}
 }
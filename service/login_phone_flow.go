@@ -0,0 +1,248 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/buger/jsonparser"
+	"github.com/go-musicfox/netease-music/util"
+)
+
+// PhoneOutcome 手机号登录、重置密码各步骤的结果
+type PhoneOutcome int
+
+const (
+	PhoneOK PhoneOutcome = iota
+	// PhoneNotRegistered 手机号未注册（501），Check 返回的 error 为 ErrPhoneNotRegistered
+	PhoneNotRegistered
+	// PhoneCooldown 距上次发送验证码不足 ResendInterval，未发出请求
+	PhoneCooldown
+	// PhoneWrongCaptcha 验证码错误或已过期（503）
+	PhoneWrongCaptcha
+	// PhoneWrongPassword 密码错误（502）
+	PhoneWrongPassword
+	// PhoneRateLimited 操作频繁（405）
+	PhoneRateLimited
+	// PhoneRiskControl 触发风控（8821、-462）
+	PhoneRiskControl
+	// PhoneFailed 参数错误、网络错误或其他业务错误
+	PhoneFailed
+)
+
+func (o PhoneOutcome) String() string {
+	switch o {
+	case PhoneOK:
+		return "ok"
+	case PhoneNotRegistered:
+		return "not_registered"
+	case PhoneCooldown:
+		return "cooldown"
+	case PhoneWrongCaptcha:
+		return "wrong_captcha"
+	case PhoneWrongPassword:
+		return "wrong_password"
+	case PhoneRateLimited:
+		return "rate_limited"
+	case PhoneRiskControl:
+		return "risk_control"
+	case PhoneFailed:
+		return "failed"
+	}
+	return "unknown"
+}
+
+// PhoneResult 手机号登录、重置密码各步骤的结果，Outcome 不为 PhoneOK 时方法同时返回 error
+type PhoneResult struct {
+	Outcome PhoneOutcome
+	// Code、Body 接口返回的状态码与响应体，未发出请求时为空
+	Code float64
+	Body []byte
+	// Exists、HasPassword、Nickname Check 得到的账号信息
+	Exists      bool
+	HasPassword bool
+	Nickname    string
+	// RetryAfter PhoneCooldown 时距离可以再次发送验证码的时间
+	RetryAfter time.Duration
+	// UserID、Session 登录成功后的用户ID与会话
+	UserID  int64
+	Session *util.Session
+}
+
+var (
+	countryCodePattern = regexp.MustCompile(`^[1-9]\d{0,3}$`)
+	phonePattern       = regexp.MustCompile(`^\d{4,15}$`)
+	chinaPhonePattern  = regexp.MustCompile(`^1\d{10}$`)
+)
+
+// PhoneLogin 手机号登录与重置密码流程
+//
+// 依次调用 Check 确认手机号已注册、SendCaptcha 发送验证码，再通过 LoginWithCaptcha 或
+// LoginWithPassword 登录；ResetPassword 使用验证码为已注册的手机号设置新密码
+type PhoneLogin struct {
+	Client *util.Client
+	// CountryCode 国家码，如 86、852，可带 "+"，默认 86
+	CountryCode string
+	Phone       string
+	// ResendInterval 两次发送验证码的最短间隔，默认 60 秒
+	ResendInterval time.Duration
+	// CheckToken 密码登录前安全检查使用的 CheckTokenProvider，见 LoginCellphoneService.CheckToken
+	CheckToken CheckTokenProvider
+
+	mu       sync.Mutex
+	lastSent time.Time
+}
+
+// validate 校验并返回不带 "+" 的国家码与手机号
+func (f *PhoneLogin) validate() (string, string, error) {
+	ctcode := strings.TrimPrefix(strings.TrimSpace(f.CountryCode), "+")
+	if ctcode == "" {
+		ctcode = "86"
+	}
+	if !countryCodePattern.MatchString(ctcode) {
+		return "", "", fmt.Errorf("%w: %q", ErrInvalidCountryCode, f.CountryCode)
+	}
+	phone := strings.TrimSpace(f.Phone)
+	if !phonePattern.MatchString(phone) || (ctcode == "86" && !chinaPhonePattern.MatchString(phone)) {
+		return "", "", fmt.Errorf("%w: %q", ErrInvalidPhone, f.Phone)
+	}
+	return ctcode, phone, nil
+}
+
+// Check 查询手机号是否已注册、是否设置了密码
+func (f *PhoneLogin) Check(ctx context.Context) (*PhoneResult, error) {
+	ctcode, phone, err := f.validate()
+	if err != nil {
+		return &PhoneResult{Outcome: PhoneFailed}, err
+	}
+	service := &CellphoneExistenceCheckService{Client: f.Client, Countrycode: ctcode, Cellphone: phone}
+	code, body, err := service.CellphoneExistenceCheckContext(ctx)
+	res := phoneResult(code, body, err)
+	if err != nil {
+		return res, err
+	}
+	exist, _ := jsonparser.GetInt(body, "exist")
+	res.Exists = exist == 1
+	res.HasPassword, _ = jsonparser.GetBoolean(body, "hasPassword")
+	res.Nickname, _ = jsonparser.GetString(body, "nickname")
+	if !res.Exists {
+		res.Outcome = PhoneNotRegistered
+		return res, ErrPhoneNotRegistered
+	}
+	return res, nil
+}
+
+// SendCaptcha 发送短信验证码，距上次发送不足 ResendInterval 时返回 PhoneCooldown 且不发出请求
+func (f *PhoneLogin) SendCaptcha(ctx context.Context) (*PhoneResult, error) {
+	ctcode, phone, err := f.validate()
+	if err != nil {
+		return &PhoneResult{Outcome: PhoneFailed}, err
+	}
+	if wait := f.ResendIn(); wait > 0 {
+		return &PhoneResult{Outcome: PhoneCooldown, RetryAfter: wait}, ErrCaptchaCooldown
+	}
+	service := &CaptchaSentService{Client: f.Client, Ctcode: ctcode, Cellphone: phone}
+	code, body, err := service.CaptchaSentContext(ctx)
+	res := phoneResult(code, body, err)
+	if err == nil {
+		f.mu.Lock()
+		f.lastSent = time.Now()
+		f.mu.Unlock()
+	}
+	return res, err
+}
+
+// ResendIn 返回距离可以再次发送验证码的时间，可以发送时返回 0
+func (f *PhoneLogin) ResendIn() time.Duration {
+	interval := f.ResendInterval
+	if interval <= 0 {
+		interval = time.Minute
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.lastSent.IsZero() {
+		return 0
+	}
+	if wait := time.Until(f.lastSent.Add(interval)); wait > 0 {
+		return wait
+	}
+	return 0
+}
+
+// VerifyCaptcha 校验短信验证码
+func (f *PhoneLogin) VerifyCaptcha(ctx context.Context, captcha string) (*PhoneResult, error) {
+	ctcode, phone, err := f.validate()
+	if err != nil {
+		return &PhoneResult{Outcome: PhoneFailed}, err
+	}
+	service := &CaptchaVerifyService{Client: f.Client, Ctcode: ctcode, Cellphone: phone, Captcha: captcha}
+	code, body, err := service.CaptchaVerifyContext(ctx)
+	return phoneResult(code, body, err), err
+}
+
+// LoginWithCaptcha 使用短信验证码登录
+func (f *PhoneLogin) LoginWithCaptcha(ctx context.Context, captcha string) (*PhoneResult, error) {
+	return f.login(ctx, &LoginCellphoneService{Captcha: captcha})
+}
+
+// LoginWithPassword 使用密码登录
+func (f *PhoneLogin) LoginWithPassword(ctx context.Context, password string) (*PhoneResult, error) {
+	return f.login(ctx, &LoginCellphoneService{Password: password, CheckToken: f.CheckToken})
+}
+
+func (f *PhoneLogin) login(ctx context.Context, service *LoginCellphoneService) (*PhoneResult, error) {
+	ctcode, phone, err := f.validate()
+	if err != nil {
+		return &PhoneResult{Outcome: PhoneFailed}, err
+	}
+	service.Client, service.Countrycode, service.Phone = f.Client, ctcode, phone
+	code, body, err := service.LoginCellphoneContext(ctx)
+	res := phoneResult(code, body, err)
+	if err != nil {
+		return res, err
+	}
+	res.UserID, _ = jsonparser.GetInt(body, "account", "id")
+	res.Nickname, _ = jsonparser.GetString(body, "profile", "nickname")
+	res.Session = f.Client.ExportSession()
+	return res, nil
+}
+
+// ResetPassword 使用短信验证码为已注册的手机号设置新密码
+//
+// 重置密码与注册使用同一个接口，为避免误注册新账号，会先确认手机号已注册
+func (f *PhoneLogin) ResetPassword(ctx context.Context, captcha, password string) (*PhoneResult, error) {
+	if res, err := f.Check(ctx); err != nil {
+		return res, err
+	}
+	ctcode, phone, _ := f.validate()
+	service := &RegisterCellphoneService{Client: f.Client, Countrycode: ctcode, Phone: phone, Captcha: captcha, Password: password}
+	code, body, err := service.RegisterCellphoneContext(ctx)
+	return phoneResult(code, body, err), err
+}
+
+// phoneResult 按状态码归类接口返回的结果
+func phoneResult(code float64, body []byte, err error) *PhoneResult {
+	res := &PhoneResult{Code: code, Body: body}
+	var apiErr *util.APIError
+	switch {
+	case err == nil:
+		res.Outcome = PhoneOK
+	case errors.Is(err, util.ErrRateLimited):
+		res.Outcome = PhoneRateLimited
+	case errors.Is(err, util.ErrRiskControl):
+		res.Outcome = PhoneRiskControl
+	case errors.As(err, &apiErr) && apiErr.Code == 501:
+		res.Outcome = PhoneNotRegistered
+	case errors.As(err, &apiErr) && apiErr.Code == 502:
+		res.Outcome = PhoneWrongPassword
+	case errors.As(err, &apiErr) && apiErr.Code == 503:
+		res.Outcome = PhoneWrongCaptcha
+	default:
+		res.Outcome = PhoneFailed
+	}
+	return res
+}
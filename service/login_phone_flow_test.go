@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-musicfox/netease-music/neteasetest"
+)
+
+func TestPhoneLogin_Captcha(t *testing.T) {
+	server := neteasetest.NewServer()
+	defer server.Close()
+	server.HandleJSON("/api/cellphone/existence/check", `{"code":200,"exist":1,"nickname":"测试用户","hasPassword":true}`)
+	server.HandleJSON("/api/sms/captcha/verify", `{"code":200,"data":true}`)
+
+	ctx := context.Background()
+	flow := &PhoneLogin{Client: server.NewClient(), CountryCode: "+852", Phone: "61234567", ResendInterval: time.Hour}
+	res, err := flow.Check(ctx)
+	if err != nil || !res.Exists || !res.HasPassword || res.Nickname != "测试用户" {
+		t.Fatalf("check: %+v, %v", res, err)
+	}
+
+	if res, err = flow.SendCaptcha(ctx); err != nil || res.Outcome != PhoneOK {
+		t.Fatalf("send: %+v, %v", res, err)
+	}
+	if req := server.LastRequest("/api/sms/captcha/sent"); req.Param("ctcode") != "852" || req.Param("cellphone") != "61234567" {
+		t.Errorf("captcha sent with %v", req.Params)
+	}
+	res, err = flow.SendCaptcha(ctx)
+	if !errors.Is(err, ErrCaptchaCooldown) || res.Outcome != PhoneCooldown || res.RetryAfter <= 0 {
+		t.Fatalf("resend: %+v, %v", res, err)
+	}
+	if n := len(server.Requests()); n != 2 {
+		t.Errorf("cooldown sent a request: %d requests", n)
+	}
+
+	if res, err = flow.VerifyCaptcha(ctx, "1234"); err != nil || res.Outcome != PhoneOK {
+		t.Fatalf("verify: %+v, %v", res, err)
+	}
+	res, err = flow.LoginWithCaptcha(ctx, "1234")
+	if err != nil || res.UserID != 1 || res.Session == nil {
+		t.Fatalf("login: %+v, %v", res, err)
+	}
+	if req := server.LastRequest("/api/login/cellphone"); req.Param("captcha") != "1234" || req.Param("countrycode") != "852" {
+		t.Errorf("login with %v", req.Params)
+	}
+}
+
+func TestPhoneLogin_Outcomes(t *testing.T) {
+	server := neteasetest.NewServer()
+	defer server.Close()
+	ctx := context.Background()
+	flow := &PhoneLogin{Client: server.NewClient(), Phone: "13800000000"}
+
+	server.HandleJSON("/api/login/cellphone", `{"code":502,"message":"密码错误"}`)
+	if res, err := flow.LoginWithPassword(ctx, "wrong"); err == nil || res.Outcome != PhoneWrongPassword {
+		t.Errorf("wrong password: %v, %v", res.Outcome, err)
+	}
+	server.HandleJSON("/api/sms/captcha/verify", `{"code":503,"message":"验证码错误"}`)
+	if res, err := flow.VerifyCaptcha(ctx, "0000"); err == nil || res.Outcome != PhoneWrongCaptcha {
+		t.Errorf("wrong captcha: %v, %v", res.Outcome, err)
+	}
+
+	// 未注册的手机号不会调用注册接口
+	server.HandleJSON("/api/cellphone/existence/check", `{"code":200,"exist":-1,"nickname":null,"hasPassword":false}`)
+	if res, err := flow.ResetPassword(ctx, "1234", "new"); !errors.Is(err, ErrPhoneNotRegistered) || res.Outcome != PhoneNotRegistered {
+		t.Errorf("reset unregistered: %v, %v", res.Outcome, err)
+	}
+	if server.LastRequest("/api/register/cellphone") != nil {
+		t.Error("register endpoint called for unregistered phone")
+	}
+
+	server.HandleJSON("/api/cellphone/existence/check", `{"code":200,"exist":1,"nickname":"测试用户","hasPassword":true}`)
+	server.HandleJSON("/api/register/cellphone", `{"code":200}`)
+	if res, err := flow.ResetPassword(ctx, "1234", "new"); err != nil || res.Outcome != PhoneOK {
+		t.Errorf("reset: %v, %v", res.Outcome, err)
+	}
+	if req := server.LastRequest("/api/register/cellphone"); req == nil || req.Param("countrycode") != "86" || req.Param("captcha") != "1234" {
+		t.Errorf("register request: %+v", req)
+	}
+
+	for _, f := range []*PhoneLogin{
+		{CountryCode: "86", Phone: "12345"},
+		{CountryCode: "abc", Phone: "13800000000"},
+		{CountryCode: "+1", Phone: "555-0100"},
+	} {
+		res, err := f.SendCaptcha(ctx)
+		if res.Outcome != PhoneFailed || !(errors.Is(err, ErrInvalidPhone) || errors.Is(err, ErrInvalidCountryCode)) {
+			t.Errorf("%s %s: %v, %v", f.CountryCode, f.Phone, res.Outcome, err)
+		}
+	}
+}
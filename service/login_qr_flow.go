@@ -0,0 +1,267 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/buger/jsonparser"
+	"github.com/go-musicfox/netease-music/util"
+	"github.com/skip2/go-qrcode"
+)
+
+// QRLoginStateType 扫码登录的状态
+type QRLoginStateType int
+
+const (
+	// QRWaiting 二维码已生成，等待扫码（801），生成新的二维码后会再次进入该状态
+	QRWaiting QRLoginStateType = iota
+	// QRScanned 已扫码，等待在 App 上确认（802）
+	QRScanned
+	// QRConfirmed 授权登录成功（803），登录流程结束
+	QRConfirmed
+	// QRExpired 二维码已失效（800），未超过 MaxRegenerate 时随后会生成新的二维码
+	QRExpired
+	// QRRiskControl 触发风控（8821），登录流程结束
+	QRRiskControl
+	// QRFailed 无法恢复的错误（未知状态码、连续网络错误超过 MaxPollErrors 等），登录流程结束
+	QRFailed
+)
+
+func (t QRLoginStateType) String() string {
+	switch t {
+	case QRWaiting:
+		return "waiting"
+	case QRScanned:
+		return "scanned"
+	case QRConfirmed:
+		return "confirmed"
+	case QRExpired:
+		return "expired"
+	case QRRiskControl:
+		return "risk_control"
+	case QRFailed:
+		return "failed"
+	}
+	return "unknown"
+}
+
+// QRLoginState 扫码登录过程中的一个状态
+type QRLoginState struct {
+	Type QRLoginStateType
+	// URL 当前二维码的内容，可通过 QRCodeText、QRCodePNG、QRCodeSVG 渲染
+	URL string
+	// Nickname、AvatarURL 扫码用户的昵称与头像，QRScanned 与 QRConfirmed 时有值
+	Nickname  string
+	AvatarURL string
+	// UserID 登录的用户ID，QRConfirmed 时有值
+	UserID int64
+	// Account 登录后 UserAccountService 返回的账号信息，QRConfirmed 时有值
+	Account []byte
+	// Session 登录后的会话，QRConfirmed 时有值，可通过 Session.Save 保存
+	Session *util.Session
+	// Err QRExpired、QRRiskControl、QRFailed 的原因
+	Err error
+}
+
+// Done 是否为结束状态
+func (s *QRLoginState) Done() bool {
+	return s.Type == QRConfirmed || s.Type == QRRiskControl || s.Type == QRFailed
+}
+
+// QRLogin 扫码登录流程
+//
+// 获取二维码后按 PollInterval 轮询扫码状态，状态变化时调用 OnState。
+// 二维码失效时自动生成新的二维码，轮询遇到网络错误等可重试的错误（见 util.Retryable）时继续轮询，
+// 登录成功后保存会话并获取账号信息
+type QRLogin struct {
+	Client *util.Client
+	// PollInterval 轮询扫码状态的间隔，默认 1 秒
+	PollInterval time.Duration
+	// MaxRegenerate 二维码失效后最多重新生成的次数，默认 3 次，小于 0 时不重新生成
+	MaxRegenerate int
+	// MaxPollErrors 轮询扫码状态时最多连续出现的可重试错误次数，默认 3 次，小于 0 时出错即结束
+	MaxPollErrors int
+	// OnState 状态变化时调用，可为 nil
+	OnState func(QRLoginState)
+}
+
+// Start 在后台运行扫码登录，依次发送每个状态，结束后关闭 channel
+func (l *QRLogin) Start(ctx context.Context) <-chan QRLoginState {
+	ch := make(chan QRLoginState, 1)
+	onState := l.OnState
+	flow := *l
+	flow.OnState = func(s QRLoginState) {
+		if onState != nil {
+			onState(s)
+		}
+		select {
+		case ch <- s:
+		case <-ctx.Done():
+		}
+	}
+	go func() {
+		defer close(ch)
+		_, _ = flow.Run(ctx)
+	}()
+	return ch
+}
+
+// Run 运行扫码登录直到结束，返回最终状态
+//
+// 登录成功时 error 为 nil；ctx 结束时返回 ctx.Err()，此时不会发送结束状态
+func (l *QRLogin) Run(ctx context.Context) (*QRLoginState, error) {
+	service := &LoginQRService{Client: l.Client}
+	interval := l.PollInterval
+	if interval <= 0 {
+		interval = time.Second
+	}
+	maxRegenerate := l.MaxRegenerate
+	if maxRegenerate == 0 {
+		maxRegenerate = 3
+	}
+	maxPollErrors := l.MaxPollErrors
+	if maxPollErrors == 0 {
+		maxPollErrors = 3
+	}
+
+	var (
+		state       QRLoginState
+		regenerated int
+		pollErrors  int
+	)
+	for {
+		if state.URL == "" {
+			_, _, url, err := service.GetKeyContext(ctx)
+			if err == nil && url == "" {
+				err = errors.New("netease: 获取二维码失败")
+			}
+			if err != nil {
+				return l.finish(ctx, QRLoginState{Type: QRFailed, Err: err})
+			}
+			state = QRLoginState{Type: QRWaiting, URL: url}
+			l.emit(state)
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return &state, ctx.Err()
+		case <-timer.C:
+		}
+
+		code, body, err := service.CheckQRContext(ctx)
+		if util.Retryable(err) {
+			if pollErrors++; maxPollErrors >= 0 && pollErrors <= maxPollErrors {
+				continue
+			}
+			return l.finish(ctx, QRLoginState{Type: QRFailed, URL: state.URL, Err: err})
+		}
+		pollErrors = 0
+		switch {
+		case code == 801:
+		case code == 802:
+			if state.Type != QRScanned {
+				state.Type = QRScanned
+				state.Nickname, _ = jsonparser.GetString(body, "nickname")
+				state.AvatarURL, _ = jsonparser.GetString(body, "avatarUrl")
+				l.emit(state)
+			}
+		case code == 803:
+			state.Type = QRConfirmed
+			return l.confirm(ctx, state)
+		case code == 800:
+			expired := QRLoginState{Type: QRExpired, URL: state.URL, Err: err}
+			if maxRegenerate < 0 || regenerated >= maxRegenerate {
+				return l.finish(ctx, expired)
+			}
+			l.emit(expired)
+			regenerated++
+			state = QRLoginState{}
+		case errors.Is(err, util.ErrRiskControl):
+			return l.finish(ctx, QRLoginState{Type: QRRiskControl, URL: state.URL, Err: err})
+		default:
+			if err == nil {
+				err = fmt.Errorf("netease: 无法识别的扫码状态 %v: %s", code, body)
+			}
+			return l.finish(ctx, QRLoginState{Type: QRFailed, URL: state.URL, Err: err})
+		}
+	}
+}
+
+// confirm 登录成功后获取账号信息并保存会话
+func (l *QRLogin) confirm(ctx context.Context, state QRLoginState) (*QRLoginState, error) {
+	_, account, err := (&UserAccountService{Client: l.Client}).AccountInfoContext(ctx)
+	if err != nil {
+		return l.finish(ctx, QRLoginState{Type: QRFailed, URL: state.URL, Err: fmt.Errorf("获取账号信息失败: %w", err)})
+	}
+	state.Account = account
+	state.UserID, _ = jsonparser.GetInt(account, "account", "id")
+	if nickname, err := jsonparser.GetString(account, "profile", "nickname"); err == nil {
+		state.Nickname = nickname
+	}
+	if avatar, err := jsonparser.GetString(account, "profile", "avatarUrl"); err == nil {
+		state.AvatarURL = avatar
+	}
+	state.Session = l.Client.ExportSession()
+	return l.finish(ctx, state)
+}
+
+// finish 发送结束状态，登录未成功时返回 state.Err
+func (l *QRLogin) finish(ctx context.Context, state QRLoginState) (*QRLoginState, error) {
+	if err := ctx.Err(); err != nil {
+		return &state, err
+	}
+	l.emit(state)
+	return &state, state.Err
+}
+
+func (l *QRLogin) emit(state QRLoginState) {
+	if l.OnState != nil {
+		l.OnState(state)
+	}
+}
+
+// QRCodeText 将 content 渲染为可以打印到终端的二维码，每个字符表示上下两个模块
+func QRCodeText(content string) (string, error) {
+	qr, err := qrcode.New(content, qrcode.Medium)
+	if err != nil {
+		return "", err
+	}
+	return qr.ToSmallString(false), nil
+}
+
+// QRCodePNG 将 content 渲染为边长 size 像素的 PNG 图片
+func QRCodePNG(content string, size int) ([]byte, error) {
+	return qrcode.Encode(content, qrcode.Medium, size)
+}
+
+// QRCodeSVG 将 content 渲染为 SVG 图片，每个模块的边长为 1，可以按需缩放
+func QRCodeSVG(content string) (string, error) {
+	qr, err := qrcode.New(content, qrcode.Medium)
+	if err != nil {
+		return "", err
+	}
+	bitmap := qr.Bitmap()
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 %d %d" shape-rendering="crispEdges">`, len(bitmap), len(bitmap))
+	fmt.Fprintf(&b, `<rect width="100%%" height="100%%" fill="#fff"/><path fill="#000" d="`)
+	for y, row := range bitmap {
+		for x := 0; x < len(row); x++ {
+			if !row[x] {
+				continue
+			}
+			// 合并同一行连续的模块
+			start := x
+			for x+1 < len(row) && row[x+1] {
+				x++
+			}
+			fmt.Fprintf(&b, "M%d %dh%dv1h-%dz", start, y, x-start+1, x-start+1)
+		}
+	}
+	b.WriteString(`"/></svg>`)
+	return b.String(), nil
+}
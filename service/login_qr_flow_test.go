@@ -0,0 +1,151 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-musicfox/netease-music/neteasetest"
+	"github.com/go-musicfox/netease-music/util"
+)
+
+func TestQRLogin(t *testing.T) {
+	server := neteasetest.NewServer()
+	defer server.Close()
+	server.Handle("/api/login/qrcode/client/login", neteasetest.Sequence(
+		`{"code":801,"message":"等待扫码"}`,
+		`{"code":800,"message":"二维码不存在或已过期"}`,
+		`{"code":801,"message":"等待扫码"}`,
+		`{"code":802,"message":"授权中","nickname":"测试用户","avatarUrl":"https://p1.music.126.net/avatar.jpg"}`,
+		`{"code":802,"message":"授权中","nickname":"测试用户","avatarUrl":"https://p1.music.126.net/avatar.jpg"}`,
+		`{"code":803,"message":"授权登陆成功"}`,
+	))
+
+	login := &QRLogin{Client: server.NewClient(), PollInterval: time.Millisecond}
+	var states []QRLoginState
+	for s := range login.Start(context.Background()) {
+		states = append(states, s)
+	}
+
+	var types []string
+	for _, s := range states {
+		types = append(types, s.Type.String())
+	}
+	if got := strings.Join(types, ","); got != "waiting,expired,waiting,scanned,confirmed" {
+		t.Fatalf("states: %s", got)
+	}
+	if states[0].URL == "" || !strings.Contains(states[0].URL, "codekey=") {
+		t.Errorf("qr url: %q", states[0].URL)
+	}
+	if states[3].Nickname != "测试用户" || states[3].AvatarURL == "" {
+		t.Errorf("scanned state: %+v", states[3])
+	}
+	done := states[4]
+	if !done.Done() || done.UserID != 1 || done.Nickname != "测试用户" || len(done.Account) == 0 {
+		t.Fatalf("confirmed state: %+v", done)
+	}
+	if done.Session == nil || done.Session.UserID != 1 {
+		t.Fatalf("session not captured: %+v", done.Session)
+	}
+	var musicU string
+	for _, c := range done.Session.Cookies {
+		if c.Name == "MUSIC_U" {
+			musicU = c.Value
+		}
+	}
+	if musicU != neteasetest.TestMusicU {
+		t.Errorf("MUSIC_U = %q", musicU)
+	}
+}
+
+func TestQRLogin_Terminal(t *testing.T) {
+	server := neteasetest.NewServer()
+	defer server.Close()
+
+	server.HandleJSON("/api/login/qrcode/client/login", `{"code":8821,"message":"需要行为验证码验证"}`)
+	login := &QRLogin{Client: server.NewClient(), PollInterval: time.Millisecond}
+	state, err := login.Run(context.Background())
+	if !errors.Is(err, util.ErrRiskControl) || state.Type != QRRiskControl {
+		t.Fatalf("state %v, err %v", state.Type, err)
+	}
+
+	// 不重新生成时二维码失效即结束
+	server.HandleJSON("/api/login/qrcode/client/login", `{"code":800,"message":"二维码不存在或已过期"}`)
+	login.MaxRegenerate = -1
+	state, err = login.Run(context.Background())
+	if !errors.Is(err, util.ErrQRExpired) || state.Type != QRExpired {
+		t.Fatalf("state %v, err %v", state.Type, err)
+	}
+
+	// ctx 结束时停止轮询
+	server.HandleJSON("/api/login/qrcode/client/login", `{"code":801,"message":"等待扫码"}`)
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := login.Run(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("err %v", err)
+	}
+}
+
+func TestQRCodeRender(t *testing.T) {
+	url := "http://music.163.com/login?codekey=test"
+	text, err := QRCodeText(url)
+	if err != nil || !strings.Contains(text, "█") {
+		t.Fatalf("text: %v\n%s", err, text)
+	}
+	png, err := QRCodePNG(url, 256)
+	if err != nil || !bytes.HasPrefix(png, []byte("\x89PNG")) {
+		t.Fatalf("png: %v", err)
+	}
+	svg, err := QRCodeSVG(url)
+	if err != nil || !strings.HasPrefix(svg, "<svg") || !strings.HasSuffix(svg, "</svg>") || !strings.Contains(svg, "M4 4h7v1h-7z") {
+		t.Fatalf("svg: %v\n%s", err, svg)
+	}
+}
+
+func TestQRLogin_TransientErrors(t *testing.T) {
+	server := neteasetest.NewServer()
+	defer server.Close()
+	var polls int
+	failing := func(n int, next neteasetest.Handler) neteasetest.Handler {
+		return func(w http.ResponseWriter, r *neteasetest.Request) {
+			if polls++; polls <= n {
+				w.WriteHeader(http.StatusBadGateway)
+				return
+			}
+			next(w, r)
+		}
+	}
+
+	// 连续的网络错误不超过 MaxPollErrors 时继续轮询
+	server.Handle("/api/login/qrcode/client/login", failing(2, neteasetest.Sequence(
+		`{"code":802,"message":"授权中","nickname":"测试用户"}`,
+		`{"code":803,"message":"授权登陆成功"}`,
+	)))
+	login := &QRLogin{Client: server.NewClient(), PollInterval: time.Millisecond}
+	state, err := login.Run(context.Background())
+	if err != nil || state.Type != QRConfirmed {
+		t.Fatalf("state %v, err %v", state.Type, err)
+	}
+
+	// 超过 MaxPollErrors 后以 QRFailed 结束
+	polls = 0
+	server.Handle("/api/login/qrcode/client/login", failing(3, neteasetest.JSON(`{"code":803,"message":"授权登陆成功"}`)))
+	login.MaxPollErrors = 2
+	state, err = login.Run(context.Background())
+	if !util.Retryable(err) || state.Type != QRFailed || polls != 3 {
+		t.Fatalf("state %v, err %v, polls %d", state.Type, err, polls)
+	}
+
+	// 无法恢复的错误立即结束
+	polls = 0
+	server.HandleJSON("/api/login/qrcode/client/login", `{"code":8000,"message":"未知错误"}`)
+	login.MaxPollErrors = 0
+	state, err = login.Run(context.Background())
+	if err == nil || state.Type != QRFailed {
+		t.Fatalf("state %v, err %v", state.Type, err)
+	}
+}
@@ -1,14 +1,18 @@
 package service
 
 import (
+	"context"
 	"encoding/json"
-	"log"
+	"errors"
+	"fmt"
 
 	"github.com/go-musicfox/netease-music/util"
 )
 
 type LoginQRService struct {
 	UniKey string `json:"unikey"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 // GetKey 获取要生成二维码的QrcodeUrl
@@ -20,26 +24,30 @@ type LoginQRService struct {
 //   - 获取到的Unikey
 //   - error
 func (service *LoginQRService) GetKey() (float64, []byte, string, error) {
+	code, bodyBytes, qrcodeUrl, err := service.GetKeyContext(context.Background())
+	return code, bodyBytes, qrcodeUrl, legacyError(err)
+}
+
+func (service *LoginQRService) GetKeyContext(ctx context.Context) (float64, []byte, string, error) {
 	data := map[string]interface{}{
 		"type":         1,
 		"noCheckToken": true,
 	}
 
 	api := "https://music.163.com/weapi/login/qrcode/unikey"
-	code, bodyBytes, err := util.CallWeapi(api, data)
+	code, bodyBytes, err := service.Client.CallWeapiContext(ctx, api, data)
 	if err != nil {
 		return code, bodyBytes, "", err
 	}
 	if code != 200 || len(bodyBytes) == 0 {
 		return code, bodyBytes, "", err
 	}
-	err = json.Unmarshal(bodyBytes, service)
-	if err != nil {
-		log.Fatalf("Error unmarshalling bodybytes: %v", err)
+	if err = json.Unmarshal(bodyBytes, service); err != nil {
+		return code, bodyBytes, "", fmt.Errorf("error unmarshalling bodybytes: %w", err)
 	}
 
 	// 生成 chainId，这个是新版本新加的参数
-	cookieJar := util.GetGlobalCookieJar()
+	cookieJar := service.Client.CookieJar()
 	chainID := util.GenerateChainID(cookieJar)
 	qrcodeUrl := ("http://music.163.com/login?codekey=" +
 		service.UniKey + "&chainId=" + chainID)
@@ -47,6 +55,15 @@ func (service *LoginQRService) GetKey() (float64, []byte, string, error) {
 }
 
 func (service *LoginQRService) CheckQR() (float64, []byte, error) {
+	code, bodyBytes, err := service.CheckQRContext(context.Background())
+	return code, bodyBytes, legacyError(err)
+}
+
+// CheckQRContext 查询扫码状态
+//
+// 800、801、802 返回的 error 分别满足 errors.Is(err, util.ErrQRExpired)、
+// util.ErrQRWaiting、util.ErrQRScanned；803 表示授权登录成功，此时 error 为 nil
+func (service *LoginQRService) CheckQRContext(ctx context.Context) (float64, []byte, error) {
 	if service.UniKey == "" {
 		return 0, nil, nil
 	}
@@ -57,7 +74,10 @@ func (service *LoginQRService) CheckQR() (float64, []byte, error) {
 	}
 
 	api := "https://music.163.com/weapi/login/qrcode/client/login"
-	code, bodyBytes, err := util.CallWeapi(api, data)
+	code, bodyBytes, err := service.Client.CallWeapiContext(ctx, api, data)
+	if errors.Is(err, util.ErrQRAuthorized) {
+		err = nil
+	}
 	if err != nil {
 		return code, bodyBytes, err
 	}
@@ -4,11 +4,20 @@ import (
 	"testing"
 	"time"
 
+	"github.com/go-musicfox/netease-music/neteasetest"
 	"github.com/skip2/go-qrcode"
 )
 
 func TestLoginQRService(t *testing.T) {
-	service := &LoginQRService{}
+	server := neteasetest.NewServer()
+	defer server.Close()
+	server.Handle("/api/login/qrcode/client/login", neteasetest.Sequence(
+		`{"code":801,"message":"等待扫码"}`,
+		`{"code":802,"message":"授权中","nickname":"测试用户"}`,
+		`{"code":803,"message":"授权登陆成功"}`,
+	))
+
+	service := &LoginQRService{Client: server.NewClient()}
 	code, _, qrcodeUrl, err := service.GetKey()
 	if err != nil {
 		t.Errorf("error: %s", err.Error())
@@ -50,11 +59,11 @@ func TestLoginQRService(t *testing.T) {
 		default:
 			t.Fatalf("错误，无法识别的状态：%s", string(resp))
 		}
-		time.Sleep(1 * time.Second)
+		time.Sleep(10 * time.Millisecond)
 	}
 Success:
 	// 扫码成功获取用户信息
-	accountService := &UserAccountService{}
+	accountService := &UserAccountService{Client: service.Client}
 	code, _ = accountService.AccountInfo()
 	if code != 200 {
 		t.Fatalf("code error: %f", code)
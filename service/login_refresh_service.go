@@ -1,25 +1,29 @@
 package service
 
 import (
-	"net/http"
+	"context"
 
 	"github.com/go-musicfox/netease-music/util"
 )
 
 type LoginRefreshService struct {
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *LoginRefreshService) LoginRefresh() (float64, []byte) {
+	code, reBody, _ := service.LoginRefreshContext(context.Background())
+	return code, reBody
+}
 
-	cookiesOS := &http.Cookie{Name: "os", Value: "pc"}
+func (service *LoginRefreshService) LoginRefreshContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto:  "weapi",
 		Ua:      "pc",
-		Cookies: []*http.Cookie{cookiesOS},
+		Profile: util.WebProfile(),
 	}
 	data := make(map[string]string)
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/weapi/login/token/refresh`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/weapi/login/token/refresh`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
@@ -1,20 +1,28 @@
 package service
 
 import (
+	"context"
+
 	"github.com/go-musicfox/netease-music/util"
 )
 
 type LogoutService struct {
+	Client *util.Client `json:"-" form:"-"`
 }
 
 // Logout 注销登录
 func (service *LogoutService) Logout() (float64, []byte, error) {
+	code, bodyBytes, err := service.LogoutContext(context.Background())
+	return code, bodyBytes, legacyError(err)
+}
+
+func (service *LogoutService) LogoutContext(ctx context.Context) (float64, []byte, error) {
 	api := "https://music.163.com/weapi/logout"
 	data := make(map[string]interface{})
-	cookiejar := util.GetGlobalCookieJar()
+	cookiejar := service.Client.CookieJar()
 	csrfToken := util.GetCsrfToken(cookiejar)
 	data["csrf_token"] = csrfToken
-	code, bodyBytes, err := util.CallWeapi(api, data)
+	code, bodyBytes, err := service.Client.CallWeapiContext(ctx, api, data)
 	if err != nil {
 		return code, bodyBytes, err
 	}
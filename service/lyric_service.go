@@ -1,22 +1,27 @@
 package service
 
 import (
-	"net/http"
+	"context"
 
 	"github.com/go-musicfox/netease-music/util"
 )
 
 type LyricService struct {
 	ID string `json:"id" form:"id"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *LyricService) Lyric() (float64, []byte) {
+	code, reBody, _ := service.LyricContext(context.Background())
+	return code, reBody
+}
 
-	cookiesOS := &http.Cookie{Name: "os", Value: "pc"}
+func (service *LyricService) LyricContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto:  "linuxapi",
-		Cookies: []*http.Cookie{cookiesOS},
+		Profile: util.WebProfile(),
 	}
 	data := make(map[string]string)
 	data["id"] = service.ID
@@ -24,7 +29,7 @@ func (service *LyricService) Lyric() (float64, []byte) {
 	data["kv"] = "-1"
 	data["tv"] = "-1"
 
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/api/song/lyric`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/api/song/lyric`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
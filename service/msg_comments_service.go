@@ -1,6 +1,8 @@
 package service
 
 import (
+	"context"
+
 	"github.com/go-musicfox/netease-music/util"
 )
 
@@ -8,9 +10,16 @@ type MsgCommentsService struct {
 	UID        string `json:"uid" form:"uid"`
 	Limit      string `json:"limit" form:"limit"`
 	BeforeTime string `json:"before" form:"before"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *MsgCommentsService) MsgComments() (float64, []byte) {
+	code, reBody, _ := service.MsgCommentsContext(context.Background())
+	return code, reBody
+}
+
+func (service *MsgCommentsService) MsgCommentsContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto: "weapi",
@@ -28,7 +37,7 @@ func (service *MsgCommentsService) MsgComments() (float64, []byte) {
 		data["beforeTime"] = service.BeforeTime
 	}
 	data["order"] = "true"
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/api/v1/user/comments/`+service.UID, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/api/v1/user/comments/`+service.UID, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
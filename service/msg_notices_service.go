@@ -1,15 +1,24 @@
 package service
 
 import (
+	"context"
+
 	"github.com/go-musicfox/netease-music/util"
 )
 
 type MsgNoticesService struct {
 	Limit    string `json:"limit" form:"limit"`
 	LastTime string `json:"lasttime" form:"lasttime"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *MsgNoticesService) MsgNotices() (float64, []byte) {
+	code, reBody, _ := service.MsgNoticesContext(context.Background())
+	return code, reBody
+}
+
+func (service *MsgNoticesService) MsgNoticesContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto: "weapi",
@@ -25,7 +34,7 @@ func (service *MsgNoticesService) MsgNotices() (float64, []byte) {
 	} else {
 		data["time"] = service.LastTime
 	}
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/api/msg/notices`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/api/msg/notices`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
@@ -1,7 +1,7 @@
 package service
 
 import (
-	"net/http"
+	"context"
 
 	"github.com/go-musicfox/netease-music/util"
 )
@@ -10,15 +10,20 @@ type MsgPrivateHistoryService struct {
 	UID   string `json:"uid" form:"uid"`
 	Limit string `json:"limit" form:"limit"`
 	Time  string `json:"before" form:"before"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *MsgPrivateHistoryService) MsgPrivateHistory() (float64, []byte) {
+	code, reBody, _ := service.MsgPrivateHistoryContext(context.Background())
+	return code, reBody
+}
 
-	cookiesOS := &http.Cookie{Name: "os", Value: "pc"}
+func (service *MsgPrivateHistoryService) MsgPrivateHistoryContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto:  "weapi",
-		Cookies: []*http.Cookie{cookiesOS},
+		Profile: util.WebProfile(),
 	}
 	data := make(map[string]string)
 	data["userId"] = service.UID
@@ -33,7 +38,7 @@ func (service *MsgPrivateHistoryService) MsgPrivateHistory() (float64, []byte) {
 		data["offset"] = service.Time
 	}
 	data["order"] = "true"
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/api/msg/private/history`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/api/msg/private/history`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
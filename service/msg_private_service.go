@@ -1,6 +1,8 @@
 package service
 
 import (
+	"context"
+
 	"github.com/go-musicfox/netease-music/util"
 )
 
@@ -8,9 +10,16 @@ type MsgPrivateService struct {
 	ID     string `json:"id" form:"id"`
 	Limit  string `json:"limit" form:"limit"`
 	Offset string `json:"offset" form:"offset"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *MsgPrivateService) MsgPrivate() (float64, []byte) {
+	code, reBody, _ := service.MsgPrivateContext(context.Background())
+	return code, reBody
+}
+
+func (service *MsgPrivateService) MsgPrivateContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto: "weapi",
@@ -27,7 +36,7 @@ func (service *MsgPrivateService) MsgPrivate() (float64, []byte) {
 		data["offset"] = service.Offset
 	}
 	data["order"] = "true"
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/api/msg/private/users`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/api/msg/private/users`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
@@ -1,6 +1,7 @@
 package service
 
 import (
+	"context"
 	"encoding/json"
 
 	"github.com/go-musicfox/netease-music/util"
@@ -12,9 +13,16 @@ type MvAllService struct {
 	Area   string `json:"area" form:"area"`
 	Type   string `json:"type" form:"type"`
 	Order  string `json:"order" form:"order"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *MvAllService) MvAll() (float64, []byte) {
+	code, reBody, _ := service.MvAllContext(context.Background())
+	return code, reBody
+}
+
+func (service *MvAllService) MvAllContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto: "weapi",
@@ -48,7 +56,7 @@ func (service *MvAllService) MvAll() (float64, []byte) {
 		data["offset"] = service.Offset
 	}
 	data["order"] = "true"
-	code, reBody, _ := util.CreateRequest("POST", `https://interface.music.163.com/api/mv/all`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://interface.music.163.com/api/mv/all`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
@@ -1,14 +1,23 @@
 package service
 
 import (
+	"context"
+
 	"github.com/go-musicfox/netease-music/util"
 )
 
 type MvDetailInfoService struct {
 	ID string `json:"mvid" form:"mvid"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *MvDetailInfoService) MvDetailInfo() (float64, []byte) {
+	code, reBody, _ := service.MvDetailInfoContext(context.Background())
+	return code, reBody
+}
+
+func (service *MvDetailInfoService) MvDetailInfoContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto: "weapi",
@@ -16,7 +25,7 @@ func (service *MvDetailInfoService) MvDetailInfo() (float64, []byte) {
 	data := make(map[string]string)
 	data["threadid"] = "R_MV_5_" + service.ID
 	data["composeliked"] = "true"
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/api/comment/commentthread/info`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/api/comment/commentthread/info`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
@@ -1,14 +1,23 @@
 package service
 
 import (
+	"context"
+
 	"github.com/go-musicfox/netease-music/util"
 )
 
 type MvDetailService struct {
 	ID string `json:"mvid" form:"mvid"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *MvDetailService) MvDetail() (float64, []byte) {
+	code, reBody, _ := service.MvDetailContext(context.Background())
+	return code, reBody
+}
+
+func (service *MvDetailService) MvDetailContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto: "weapi",
@@ -16,7 +25,7 @@ func (service *MvDetailService) MvDetail() (float64, []byte) {
 	data := make(map[string]string)
 	data["id"] = service.ID
 
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/api/v1/mv/detail`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/api/v1/mv/detail`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
@@ -1,15 +1,24 @@
 package service
 
 import (
+	"context"
+
 	"github.com/go-musicfox/netease-music/util"
 )
 
 type MvExclusiveRcmdService struct {
 	Limit  string `json:"limit" form:"limit"`
 	Offset string `json:"offset" form:"offset"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *MvExclusiveRcmdService) MvExclusiveRcmd() (float64, []byte) {
+	code, reBody, _ := service.MvExclusiveRcmdContext(context.Background())
+	return code, reBody
+}
+
+func (service *MvExclusiveRcmdService) MvExclusiveRcmdContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto: "weapi",
@@ -26,7 +35,7 @@ func (service *MvExclusiveRcmdService) MvExclusiveRcmd() (float64, []byte) {
 		data["offset"] = service.Offset
 	}
 	data["order"] = "true"
-	code, reBody, _ := util.CreateRequest("POST", `https://interface.music.163.com/api/mv/exclusive/rcmd`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://interface.music.163.com/api/mv/exclusive/rcmd`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
@@ -1,15 +1,24 @@
 package service
 
 import (
+	"context"
+
 	"github.com/go-musicfox/netease-music/util"
 )
 
 type MvFirstService struct {
 	Area  string `json:"area" form:"area"`
 	Limit string `json:"limit" form:"limit"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *MvFirstService) MvFirst() (float64, []byte) {
+	code, reBody, _ := service.MvFirstContext(context.Background())
+	return code, reBody
+}
+
+func (service *MvFirstService) MvFirstContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto: "weapi",
@@ -23,7 +32,7 @@ func (service *MvFirstService) MvFirst() (float64, []byte) {
 	}
 
 	data["order"] = "true"
-	code, reBody, _ := util.CreateRequest("POST", `https://interface.music.163.com/weapi/mv/first`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://interface.music.163.com/weapi/mv/first`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
@@ -1,15 +1,24 @@
 package service
 
 import (
+	"context"
+
 	"github.com/go-musicfox/netease-music/util"
 )
 
 type MvSubService struct {
 	T    string `json:"t" form:"t"`
 	MvId string `json:"mvid" form:"mvid"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *MvSubService) MvSub() (float64, []byte) {
+	code, reBody, _ := service.MvSubContext(context.Background())
+	return code, reBody
+}
+
+func (service *MvSubService) MvSubContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto: "weapi",
@@ -25,7 +34,7 @@ func (service *MvSubService) MvSub() (float64, []byte) {
 	data["mvId"] = service.MvId
 	data["mvIds"] = "[" + service.MvId + "]"
 
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/weapi/mv/`+service.T, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/weapi/mv/`+service.T, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
@@ -1,15 +1,24 @@
 package service
 
 import (
+	"context"
+
 	"github.com/go-musicfox/netease-music/util"
 )
 
 type MvSublistService struct {
 	Limit  string `json:"limit" form:"limit"`
 	Offset string `json:"offset" form:"offset"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *MvSublistService) MvSublist() (float64, []byte) {
+	code, reBody, _ := service.MvSublistContext(context.Background())
+	return code, reBody
+}
+
+func (service *MvSublistService) MvSublistContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto: "weapi",
@@ -26,7 +35,7 @@ func (service *MvSublistService) MvSublist() (float64, []byte) {
 	data["offset"] = service.Offset
 	data["total"] = "true"
 
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/weapi/cloudvideo/allvideo/sublist`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/weapi/cloudvideo/allvideo/sublist`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
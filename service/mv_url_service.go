@@ -1,7 +1,7 @@
 package service
 
 import (
-	"net/http"
+	"context"
 
 	"github.com/go-musicfox/netease-music/util"
 )
@@ -9,15 +9,20 @@ import (
 type MvUrlService struct {
 	ID string `json:"id" form:"id"`
 	R  string `json:"r" form:"r"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *MvUrlService) MvUrl() (float64, []byte) {
+	code, reBody, _ := service.MvUrlContext(context.Background())
+	return code, reBody
+}
 
-	cookiesOS := &http.Cookie{Name: "os", Value: "pc"}
+func (service *MvUrlService) MvUrlContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto:  "weapi",
-		Cookies: []*http.Cookie{cookiesOS},
+		Profile: util.WebProfile(),
 	}
 	data := make(map[string]string)
 	data["id"] = service.ID
@@ -27,7 +32,7 @@ func (service *MvUrlService) MvUrl() (float64, []byte) {
 		data["r"] = service.R
 	}
 
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/weapi/song/enhance/play/mv/url`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/weapi/song/enhance/play/mv/url`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
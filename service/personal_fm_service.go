@@ -1,19 +1,27 @@
 package service
 
 import (
+	"context"
+
 	"github.com/go-musicfox/netease-music/util"
 )
 
 type PersonalFmService struct {
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *PersonalFmService) PersonalFm() (float64, []byte) {
+	code, reBody, _ := service.PersonalFmContext(context.Background())
+	return code, reBody
+}
+
+func (service *PersonalFmService) PersonalFmContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto: "weapi",
 	}
 	data := make(map[string]string)
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/weapi/v1/radio/get`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/weapi/v1/radio/get`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
@@ -1,6 +1,8 @@
 package service
 
 import (
+	"context"
+
 	"github.com/go-musicfox/netease-music/util"
 )
 
@@ -8,16 +10,23 @@ type PersonalizedDjprogramService struct {
 	ID     string `json:"id" form:"id"`
 	Limit  string `json:"limit" form:"limit"`
 	Offset string `json:"offset" form:"offset"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *PersonalizedDjprogramService) PersonalizedDjprogram() (float64, []byte) {
+	code, reBody, _ := service.PersonalizedDjprogramContext(context.Background())
+	return code, reBody
+}
+
+func (service *PersonalizedDjprogramService) PersonalizedDjprogramContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto: "weapi",
 	}
 	data := make(map[string]string)
 
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/weapi/personalized/djprogram`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/weapi/personalized/djprogram`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
@@ -1,13 +1,21 @@
 package service
 
 import (
+	"context"
+
 	"github.com/go-musicfox/netease-music/util"
 )
 
 type PersonalizedNewsongService struct {
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *PersonalizedNewsongService) PersonalizedNewsong() (float64, []byte) {
+	code, reBody, _ := service.PersonalizedNewsongContext(context.Background())
+	return code, reBody
+}
+
+func (service *PersonalizedNewsongService) PersonalizedNewsongContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto: "weapi",
@@ -15,7 +23,7 @@ func (service *PersonalizedNewsongService) PersonalizedNewsong() (float64, []byt
 	data := make(map[string]string)
 
 	data["type"] = "recommend"
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/weapi/personalized/newsong`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/weapi/personalized/newsong`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
@@ -1,20 +1,28 @@
 package service
 
 import (
+	"context"
+
 	"github.com/go-musicfox/netease-music/util"
 )
 
 type PersonalizedPrivatecontentService struct {
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *PersonalizedPrivatecontentService) PersonalizedPrivatecontent() (float64, []byte) {
+	code, reBody, _ := service.PersonalizedPrivatecontentContext(context.Background())
+	return code, reBody
+}
+
+func (service *PersonalizedPrivatecontentService) PersonalizedPrivatecontentContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto: "weapi",
 	}
 	data := make(map[string]string)
 
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/weapi/personalized/privatecontent`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/weapi/personalized/privatecontent`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
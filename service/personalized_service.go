@@ -1,22 +1,27 @@
 package service
 
 import (
-	"net/http"
+	"context"
 
 	"github.com/go-musicfox/netease-music/util"
 )
 
 type PersonalizedService struct {
 	Limit string `json:"limit" form:"limit"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *PersonalizedService) Personalized() (float64, []byte) {
+	code, reBody, _ := service.PersonalizedContext(context.Background())
+	return code, reBody
+}
 
-	cookiesOS := &http.Cookie{Name: "os", Value: "pc"}
+func (service *PersonalizedService) PersonalizedContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto:  "weapi",
-		Cookies: []*http.Cookie{cookiesOS},
+		Profile: util.WebProfile(),
 	}
 	data := make(map[string]string)
 	if service.Limit == "" {
@@ -26,7 +31,7 @@ func (service *PersonalizedService) Personalized() (float64, []byte) {
 	}
 	data["order"] = "true"
 	data["n"] = "1000"
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/weapi/personalized/playlist`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/weapi/personalized/playlist`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
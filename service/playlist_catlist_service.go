@@ -1,18 +1,27 @@
 package service
 
 import (
+	"context"
+
 	"github.com/go-musicfox/netease-music/util"
 )
 
-type PlaylistCatlistService struct{}
+type PlaylistCatlistService struct {
+	Client *util.Client `json:"-" form:"-"`
+}
 
 func (service *PlaylistCatlistService) PlaylistCatlist() (float64, []byte) {
+	code, reBody, _ := service.PlaylistCatlistContext(context.Background())
+	return code, reBody
+}
+
+func (service *PlaylistCatlistService) PlaylistCatlistContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto: "weapi",
 	}
 	data := make(map[string]string)
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/weapi/playlist/catalogue`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/weapi/playlist/catalogue`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
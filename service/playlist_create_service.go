@@ -1,7 +1,7 @@
 package service
 
 import (
-	"net/http"
+	"context"
 
 	"github.com/go-musicfox/netease-music/util"
 )
@@ -9,15 +9,20 @@ import (
 type PlaylistCreateService struct {
 	Name    string `json:"name" form:"name"`
 	Privacy string `json:"privacy" form:"privacy"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *PlaylistCreateService) PlaylistCreate() (float64, []byte) {
+	code, reBody, _ := service.PlaylistCreateContext(context.Background())
+	return code, reBody
+}
 
-	cookiesOS := &http.Cookie{Name: "os", Value: "pc"}
+func (service *PlaylistCreateService) PlaylistCreateContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto:  "weapi",
-		Cookies: []*http.Cookie{cookiesOS},
+		Profile: util.WebProfile(),
 	}
 	data := make(map[string]string)
 	if service.Privacy != "10" {
@@ -25,7 +30,7 @@ func (service *PlaylistCreateService) PlaylistCreate() (float64, []byte) {
 	}
 	data["name"] = service.Name
 	data["privacy"] = service.Privacy
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/weapi/playlist/create`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/weapi/playlist/create`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
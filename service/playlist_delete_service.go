@@ -1,27 +1,32 @@
 package service
 
 import (
-	"net/http"
+	"context"
 
 	"github.com/go-musicfox/netease-music/util"
 )
 
 type PlaylistDeleteService struct {
 	ID string `json:"id" form:"id"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *PlaylistDeleteService) PlaylistDelete() (float64, []byte) {
+	code, reBody, _ := service.PlaylistDeleteContext(context.Background())
+	return code, reBody
+}
 
-	cookiesOS := &http.Cookie{Name: "os", Value: "pc"}
+func (service *PlaylistDeleteService) PlaylistDeleteContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto:  "weapi",
-		Cookies: []*http.Cookie{cookiesOS},
+		Profile: util.WebProfile(),
 	}
 	data := make(map[string]string)
 	data["ids"] = "[" + service.ID + "]"
 
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/weapi/playlist/remove`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/weapi/playlist/remove`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
@@ -1,15 +1,24 @@
 package service
 
 import (
+	"context"
+
 	"github.com/go-musicfox/netease-music/util"
 )
 
 type PlaylistDescUpdateService struct {
 	Id   string `json:"id" form:"id"`
 	Desc string `json:"desc" form:"desc"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *PlaylistDescUpdateService) PlaylistDescUpdate() (float64, []byte) {
+	code, reBody, _ := service.PlaylistDescUpdateContext(context.Background())
+	return code, reBody
+}
+
+func (service *PlaylistDescUpdateService) PlaylistDescUpdateContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto: "eapi",
@@ -18,7 +27,7 @@ func (service *PlaylistDescUpdateService) PlaylistDescUpdate() (float64, []byte)
 	data := make(map[string]string)
 	data["id"] = service.Id
 	data["desc"] = service.Desc
-	code, reBody, _ := util.CreateRequest("POST", `http://interface3.music.163.com/eapi/playlist/desc/update`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `http://interface3.music.163.com/eapi/playlist/desc/update`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
@@ -1,15 +1,24 @@
 package service
 
 import (
+	"context"
+
 	"github.com/go-musicfox/netease-music/util"
 )
 
 type PlaylistDetailService struct {
 	Id string `json:"id" form:"id"`
 	S  string `json:"s" form:"s"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *PlaylistDetailService) PlaylistDetail() (float64, []byte) {
+	code, reBody, _ := service.PlaylistDetailContext(context.Background())
+	return code, reBody
+}
+
+func (service *PlaylistDetailService) PlaylistDetailContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto: "linuxapi",
@@ -22,7 +31,7 @@ func (service *PlaylistDetailService) PlaylistDetail() (float64, []byte) {
 	data["n"] = "100000"
 	data["s"] = service.S
 
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/weapi/v3/playlist/detail`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/weapi/v3/playlist/detail`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
@@ -1,18 +1,27 @@
 package service
 
 import (
+	"context"
+
 	"github.com/go-musicfox/netease-music/util"
 )
 
-type PlaylistHotService struct{}
+type PlaylistHotService struct {
+	Client *util.Client `json:"-" form:"-"`
+}
 
 func (service *PlaylistHotService) PlaylistHot() (float64, []byte) {
+	code, reBody, _ := service.PlaylistHotContext(context.Background())
+	return code, reBody
+}
+
+func (service *PlaylistHotService) PlaylistHotContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto: "weapi",
 	}
 	data := make(map[string]string)
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/weapi/playlist/hottags`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/weapi/playlist/hottags`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
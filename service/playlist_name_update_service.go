@@ -1,15 +1,24 @@
 package service
 
 import (
+	"context"
+
 	"github.com/go-musicfox/netease-music/util"
 )
 
 type PlaylistNameUpdateService struct {
 	Id   string `json:"id" form:"id"`
 	Name string `json:"desc" form:"name"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *PlaylistNameUpdateService) PlaylistNameUpdate() (float64, []byte) {
+	code, reBody, _ := service.PlaylistNameUpdateContext(context.Background())
+	return code, reBody
+}
+
+func (service *PlaylistNameUpdateService) PlaylistNameUpdateContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto: "eapi",
@@ -18,7 +27,7 @@ func (service *PlaylistNameUpdateService) PlaylistNameUpdate() (float64, []byte)
 	data := make(map[string]string)
 	data["id"] = service.Id
 	data["name"] = service.Name
-	code, reBody, _ := util.CreateRequest("POST", `http://interface3.music.163.com/eapi/playlist/update/name`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `http://interface3.music.163.com/eapi/playlist/update/name`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
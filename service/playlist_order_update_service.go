@@ -1,26 +1,31 @@
 package service
 
 import (
-	"net/http"
+	"context"
 
 	"github.com/go-musicfox/netease-music/util"
 )
 
 type PlaylistOrderUpdateService struct {
 	Ids string `json:"ids" form:"ids"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *PlaylistOrderUpdateService) PlaylistOrderUpdate() (float64, []byte) {
+	code, reBody, _ := service.PlaylistOrderUpdateContext(context.Background())
+	return code, reBody
+}
 
-	cookiesOS := &http.Cookie{Name: "os", Value: "pc"}
+func (service *PlaylistOrderUpdateService) PlaylistOrderUpdateContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto:  "weapi",
-		Cookies: []*http.Cookie{cookiesOS},
+		Profile: util.WebProfile(),
 	}
 	data := make(map[string]string)
 	data["id"] = service.Ids
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/api/playlist/order/update`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/api/playlist/order/update`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
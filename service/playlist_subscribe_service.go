@@ -1,15 +1,24 @@
 package service
 
 import (
+	"context"
+
 	"github.com/go-musicfox/netease-music/util"
 )
 
 type PlaylistSubscribeService struct {
 	T  string `json:"t" form:"t"`
 	ID string `json:"id" form:"id"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *PlaylistSubscribeService) PlaylistSubscribe() (float64, []byte) {
+	code, reBody, _ := service.PlaylistSubscribeContext(context.Background())
+	return code, reBody
+}
+
+func (service *PlaylistSubscribeService) PlaylistSubscribeContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto: "weapi",
@@ -22,7 +31,7 @@ func (service *PlaylistSubscribeService) PlaylistSubscribe() (float64, []byte) {
 		service.T = "unsubscribe"
 	}
 
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/weapi/playlist/`+service.T, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/weapi/playlist/`+service.T, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
@@ -1,15 +1,24 @@
 package service
 
 import (
+	"context"
+
 	"github.com/go-musicfox/netease-music/util"
 )
 
 type PlaylistTagsUpdateService struct {
 	Id   string `json:"id" form:"id"`
 	Tags string `json:"tags" form:"tags"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *PlaylistTagsUpdateService) PlaylistTagsUpdate() (float64, []byte) {
+	code, reBody, _ := service.PlaylistTagsUpdateContext(context.Background())
+	return code, reBody
+}
+
+func (service *PlaylistTagsUpdateService) PlaylistTagsUpdateContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto: "eapi",
@@ -18,7 +27,7 @@ func (service *PlaylistTagsUpdateService) PlaylistTagsUpdate() (float64, []byte)
 	data := make(map[string]string)
 	data["id"] = service.Id
 	data["tags"] = service.Tags
-	code, reBody, _ := util.CreateRequest("POST", `http://interface3.music.163.com/eapi/playlist/tags/update`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `http://interface3.music.163.com/eapi/playlist/tags/update`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
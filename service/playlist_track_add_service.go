@@ -1,8 +1,8 @@
 package service
 
 import (
+	"context"
 	"encoding/json"
-	"net/http"
 
 	"github.com/go-musicfox/netease-music/util"
 )
@@ -10,13 +10,19 @@ import (
 type PlaylistTrackAddService struct {
 	Id      string   `json:"id" form:"id"`
 	SongIds []string `json:"songIds" form:"songIds"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *PlaylistTrackAddService) AddTracks() (float64, []byte) {
-	cookiesOS := &http.Cookie{Name: "os", Value: "pc"}
+	code, reBody, _ := service.AddTracksContext(context.Background())
+	return code, reBody
+}
+
+func (service *PlaylistTrackAddService) AddTracksContext(ctx context.Context) (float64, []byte, error) {
 	options := &util.Options{
 		Crypto:  "weapi",
-		Cookies: []*http.Cookie{cookiesOS},
+		Profile: util.WebProfile(),
 	}
 	data := make(map[string]string)
 	data["id"] = service.Id
@@ -32,7 +38,7 @@ func (service *PlaylistTrackAddService) AddTracks() (float64, []byte) {
 		data["tracks"] = string(d)
 	}
 
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/api/playlist/track/add`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/api/playlist/track/add`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
@@ -2,27 +2,44 @@ package service
 
 import (
 	"bytes"
+	"context"
 	"math"
 	"strconv"
 	"strings"
 	"sync"
 
 	"github.com/buger/jsonparser"
+	"github.com/go-musicfox/netease-music/util"
 )
 
+// allTracksConcurrency AllTracks 同时获取歌曲详情的最大请求数
+const allTracksConcurrency = 4
+
 type PlaylistTrackAllService struct {
 	Id string `json:"id" form:"id"`
 	S  string `json:"s" form:"s"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *PlaylistTrackAllService) AllTracks() (float64, []byte) {
+	code, reBody, _ := service.AllTracksContext(context.Background())
+	return code, reBody
+}
+
+// AllTracksContext 获取歌单的全部歌曲
+//
+// 歌曲详情按每500首一页并发获取，同时最多 allTracksConcurrency 个请求，
+// 任意一页失败或 ctx 被取消时其余请求都会被取消
+func (service *PlaylistTrackAllService) AllTracksContext(ctx context.Context) (float64, []byte, error) {
 	playlistDetailService := &PlaylistDetailService{
-		Id: service.Id,
-		S:  service.S,
+		Id:     service.Id,
+		S:      service.S,
+		Client: service.Client,
 	}
-	code, reBody := playlistDetailService.PlaylistDetail()
-	if code != 200 {
-		return code, reBody
+	code, reBody, err := playlistDetailService.PlaylistDetailContext(ctx)
+	if err != nil || code != 200 {
+		return code, reBody, err
 	}
 
 	var trackIds []int64
@@ -31,7 +48,7 @@ func (service *PlaylistTrackAllService) AllTracks() (float64, []byte) {
 			trackIds = append(trackIds, id)
 		}
 	}, "playlist", "trackIds"); err != nil {
-		return code, reBody
+		return code, reBody, nil
 	}
 
 	count := len(trackIds)
@@ -39,7 +56,14 @@ func (service *PlaylistTrackAllService) AllTracks() (float64, []byte) {
 	var (
 		tracks = make([][]byte, page)
 		wg     sync.WaitGroup
+
+		errOnce  sync.Once
+		firstErr error
+		sem      = make(chan struct{}, allTracksConcurrency)
 	)
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
 	for i := 0; i < page; i++ {
 		var b strings.Builder
 		for j := 0; j < 500; j++ {
@@ -55,33 +79,49 @@ func (service *PlaylistTrackAllService) AllTracks() (float64, []byte) {
 
 		wg.Add(1)
 		go func(wg *sync.WaitGroup, page int, ids string) {
-			s := SongDetailService{Ids: ids}
-			_, resp := s.SongDetail()
+			defer wg.Done()
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-ctx.Done():
+				errOnce.Do(func() { firstErr = ctx.Err() })
+				return
+			}
+			s := SongDetailService{Ids: ids, Client: service.Client}
+			_, resp, err := s.SongDetailContext(ctx)
+			if err != nil {
+				errOnce.Do(func() {
+					firstErr = err
+					cancel()
+				})
+				return
+			}
 			var bf [][]byte
 			_, _ = jsonparser.ArrayEach(resp, func(value []byte, dataType jsonparser.ValueType, offset int, err error) {
 				bf = append(bf, value)
 			}, "songs")
 			tracks[page] = bytes.Join(bf, []byte(","))
-			wg.Done()
 		}(&wg, i, b.String())
 	}
 	wg.Wait()
+	if firstErr != nil {
+		return code, reBody, firstErr
+	}
 
-	bf := bytes.NewBufferString("[")
-	for i, track := range tracks {
-		if track == nil {
-			continue
-		}
-		bf.Write(track)
-		if i != page-1 {
-			bf.WriteString(",")
+	// 跳过没有歌曲的页，避免多出逗号
+	var pages [][]byte
+	for _, track := range tracks {
+		if len(track) > 0 {
+			pages = append(pages, track)
 		}
 	}
+	bf := bytes.NewBufferString("[")
+	bf.Write(bytes.Join(pages, []byte(",")))
 	bf.WriteString("]")
 
 	if r, err := jsonparser.Set(reBody, bf.Bytes(), "playlist", "tracks"); err == nil {
 		reBody = r
 	}
 
-	return code, reBody
+	return code, reBody, nil
 }
@@ -1,14 +1,61 @@
 package service
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
 	"testing"
+
+	"github.com/go-musicfox/netease-music/neteasetest"
 )
 
 func TestPlaylistDetailService_PlaylistDetail(t *testing.T) {
+	server := neteasetest.NewServer()
+	defer server.Close()
+
 	service := &PlaylistTrackAllService{
-		Id: "139746382",
+		Client: server.NewClient(),
+		Id:     "139746382",
 	}
 	_, resp := service.AllTracks()
 	fmt.Println(string(resp))
 }
+
+func TestPlaylistTrackAllService_EmptyPage(t *testing.T) {
+	server := neteasetest.NewServer()
+	defer server.Close()
+
+	// 501 首歌分两页获取，第二页没有返回歌曲
+	ids := make([]string, 501)
+	for i := range ids {
+		ids[i] = `{"id":` + strconv.Itoa(i+1) + `}`
+	}
+	server.HandleJSON("/api/v3/playlist/detail", `{"code":200,"playlist":{"id":1,"trackIds":[`+strings.Join(ids, ",")+`]}}`)
+	server.Handle("/api/v3/song/detail", func(w http.ResponseWriter, r *neteasetest.Request) {
+		if strings.HasPrefix(r.Param("ids"), "[501") {
+			neteasetest.JSON(`{"code":200,"songs":[]}`)(w, r)
+			return
+		}
+		neteasetest.JSON(`{"code":200,"songs":[{"id":1},{"id":2}]}`)(w, r)
+	})
+
+	service := &PlaylistTrackAllService{Client: server.NewClient(), Id: "1"}
+	code, resp, err := service.AllTracksContext(context.Background())
+	if err != nil || code != 200 {
+		t.Fatalf("code %f, err %v", code, err)
+	}
+	var result struct {
+		Playlist struct {
+			Tracks []struct{ ID int64 } `json:"tracks"`
+		} `json:"playlist"`
+	}
+	if err := json.Unmarshal(resp, &result); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, resp)
+	}
+	if len(result.Playlist.Tracks) != 2 {
+		t.Fatalf("tracks: %+v", result.Playlist.Tracks)
+	}
+}
@@ -1,6 +1,7 @@
 package service
 
 import (
+	"context"
 	"encoding/json"
 
 	"github.com/go-musicfox/netease-music/util"
@@ -10,9 +11,16 @@ type PlaylistTracksService struct {
 	Op       string   `json:"op" form:"op"`
 	Pid      string   `json:"pid" form:"pid"`
 	TrackIds []string `json:"trackIds" form:"trackIds"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *PlaylistTracksService) PlaylistTracks() (float64, []byte) {
+	code, reBody, _ := service.PlaylistTracksContext(context.Background())
+	return code, reBody
+}
+
+func (service *PlaylistTracksService) PlaylistTracksContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto: "weapi",
@@ -27,7 +35,7 @@ func (service *PlaylistTracksService) PlaylistTracks() (float64, []byte) {
 	}
 
 	data["imme"] = "true"
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/api/playlist/manipulate/tracks`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/api/playlist/manipulate/tracks`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
@@ -1,7 +1,7 @@
 package service
 
 import (
-	"net/http"
+	"context"
 
 	"github.com/go-musicfox/netease-music/util"
 )
@@ -11,21 +11,26 @@ type PlaylistUpdateService struct {
 	Name string `json:"name" form:"name"`
 	Desc string `json:"desc" form:"desc"`
 	Tags string `json:"tags" form:"tags"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *PlaylistUpdateService) PlaylistUpdate() (float64, []byte) {
+	code, reBody, _ := service.PlaylistUpdateContext(context.Background())
+	return code, reBody
+}
 
-	cookiesOS := &http.Cookie{Name: "os", Value: "pc"}
+func (service *PlaylistUpdateService) PlaylistUpdateContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto:  "weapi",
-		Cookies: []*http.Cookie{cookiesOS},
+		Profile: util.WebProfile(),
 	}
 	data := make(map[string]string)
 	data["/api/playlist/desc/update"] = `{"id":` + service.Id + `,"desc":"` + service.Desc + `"}`
 	data["/api/playlist/tags/update"] = `{"id":` + service.Id + `,"tags":"` + service.Tags + `"}`
 	data["/api/playlist/update/name"] = `{"id":` + service.Id + `,"name":"` + service.Name + `"}`
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/weapi/batch`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/weapi/batch`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
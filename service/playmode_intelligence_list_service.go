@@ -1,6 +1,8 @@
 package service
 
 import (
+	"context"
+
 	"github.com/go-musicfox/netease-music/util"
 )
 
@@ -9,9 +11,16 @@ type PlaymodeIntelligenceListService struct {
 	PlaylistId   string `json:"pid" form:"pid"`
 	StartMusicId string `json:"sid" form:"sid"`
 	Count        string `json:"count" form:"count"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *PlaymodeIntelligenceListService) PlaymodeIntelligenceList() (float64, []byte) {
+	code, reBody, _ := service.PlaymodeIntelligenceListContext(context.Background())
+	return code, reBody
+}
+
+func (service *PlaymodeIntelligenceListService) PlaymodeIntelligenceListContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto: "weapi",
@@ -32,7 +41,7 @@ func (service *PlaymodeIntelligenceListService) PlaymodeIntelligenceList() (floa
 		data["count"] = service.Count
 	}
 
-	code, reBody, _ := util.CreateRequest("POST", `http://music.163.com/weapi/playmode/intelligence/list`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `http://music.163.com/weapi/playmode/intelligence/list`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
@@ -1,6 +1,8 @@
 package service
 
 import (
+	"context"
+
 	"github.com/go-musicfox/netease-music/util"
 )
 
@@ -8,9 +10,16 @@ type ProgramRecommendService struct {
 	CateId string `json:"type" form:"type"`
 	Limit  string `json:"limit" form:"limit"`
 	Offset string `json:"offset" form:"offset"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *ProgramRecommendService) ProgramRecommend() (float64, []byte) {
+	code, reBody, _ := service.ProgramRecommendContext(context.Background())
+	return code, reBody
+}
+
+func (service *ProgramRecommendService) ProgramRecommendContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto: "weapi",
@@ -28,7 +37,7 @@ func (service *ProgramRecommendService) ProgramRecommend() (float64, []byte) {
 		data["offset"] = service.Offset
 	}
 	data["order"] = "true"
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/weapi/program/recommend/v1`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/weapi/program/recommend/v1`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
@@ -1,6 +1,8 @@
 package service
 
 import (
+	"context"
+
 	"github.com/go-musicfox/netease-music/util"
 )
 
@@ -9,9 +11,16 @@ type RebindService struct {
 	Phone      string `json:"phone" form:"phone"`
 	Oldcaptcha string `json:"oldcaptcha" form:"oldcaptcha"`
 	Ctcode     string `json:"ctcode" form:"ctcode"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *RebindService) Rebind() (float64, []byte) {
+	code, reBody, _ := service.RebindContext(context.Background())
+	return code, reBody
+}
+
+func (service *RebindService) RebindContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto: "weapi",
@@ -23,7 +32,7 @@ func (service *RebindService) Rebind() (float64, []byte) {
 	data["captcha"] = service.Captcha
 	data["oldcaptcha"] = service.Oldcaptcha
 
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/api/user/replaceCellphone`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/api/user/replaceCellphone`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
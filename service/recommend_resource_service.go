@@ -1,19 +1,27 @@
 package service
 
 import (
+	"context"
+
 	"github.com/go-musicfox/netease-music/util"
 )
 
 type RecommendResourceService struct {
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *RecommendResourceService) RecommendResource() (float64, []byte) {
+	code, reBody, _ := service.RecommendResourceContext(context.Background())
+	return code, reBody
+}
+
+func (service *RecommendResourceService) RecommendResourceContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto: "weapi",
 	}
 	data := make(map[string]string)
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/weapi/v1/discovery/recommend/resource`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/weapi/v1/discovery/recommend/resource`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
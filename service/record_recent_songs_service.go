@@ -1,14 +1,23 @@
 package service
 
 import (
+	"context"
+
 	"github.com/go-musicfox/netease-music/util"
 )
 
 type RecordRecentSongsService struct {
 	Limit string `json:"limit" form:"limit"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *RecordRecentSongsService) RecordRecentSongs() (float64, []byte, error) {
+	code, bodyBytes, err := service.RecordRecentSongsContext(context.Background())
+	return code, bodyBytes, legacyError(err)
+}
+
+func (service *RecordRecentSongsService) RecordRecentSongsContext(ctx context.Context) (float64, []byte, error) {
 	data := make(map[string]any)
 	if service.Limit == "" {
 		data["limit"] = "100"
@@ -16,7 +25,7 @@ func (service *RecordRecentSongsService) RecordRecentSongs() (float64, []byte, e
 		data["limit"] = service.Limit
 	}
 	api := "https://music.163.com/api/play-record/song/list"
-	code, reBody, err := util.CallWeapi(api, data)
+	code, reBody, err := service.Client.CallWeapiContext(ctx, api, data)
 
 	return code, reBody, err
 }
@@ -0,0 +1,38 @@
+package service
+
+import (
+	"context"
+
+	"github.com/go-musicfox/netease-music/util"
+)
+
+// RegisterAnonimousService 游客注册，成功后服务端下发游客凭证 MUSIC_A
+type RegisterAnonimousService struct {
+	// DeviceId 注册使用的设备ID，为空时使用 Client 的设备ID
+	DeviceId string `json:"deviceId" form:"deviceId"`
+
+	Client *util.Client `json:"-" form:"-"`
+}
+
+func (service *RegisterAnonimousService) RegisterAnonimous() (float64, []byte) {
+	code, reBody, _ := service.RegisterAnonimousContext(context.Background())
+	return code, reBody
+}
+
+func (service *RegisterAnonimousService) RegisterAnonimousContext(ctx context.Context) (float64, []byte, error) {
+
+	options := &util.Options{
+		Crypto:  "weapi",
+		Profile: util.IOSProfile(),
+	}
+	deviceId := service.DeviceId
+	if deviceId == "" {
+		deviceId = service.Client.DeviceId()
+	}
+	data := make(map[string]string)
+	data["username"] = util.AnonymousUsername(deviceId)
+
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/weapi/register/anonimous`, data, options)
+
+	return code, reBody, err
+}
@@ -1,27 +1,33 @@
 package service
 
 import (
+	"context"
 	"crypto/md5"
 	"encoding/hex"
-	"net/http"
 
 	"github.com/go-musicfox/netease-music/util"
 )
 
 type RegisterCellphoneService struct {
-	Phone    string `json:"phone" form:"phone"`
-	Captcha  string `json:"captcha" form:"captcha"`
-	Password string `json:"password" form:"password"`
-	Nickname string `json:"nickname" form:"nickname"`
+	Phone       string `json:"phone" form:"phone"`
+	Countrycode string `json:"countrycode" form:"countrycode"`
+	Captcha     string `json:"captcha" form:"captcha"`
+	Password    string `json:"password" form:"password"`
+	Nickname    string `json:"nickname" form:"nickname"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *RegisterCellphoneService) RegisterCellphone() (float64, []byte) {
+	code, reBody, _ := service.RegisterCellphoneContext(context.Background())
+	return code, reBody
+}
 
-	cookiesOS := &http.Cookie{Name: "os", Value: "pc"}
+func (service *RegisterCellphoneService) RegisterCellphoneContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto:  "weapi",
-		Cookies: []*http.Cookie{cookiesOS},
+		Profile: util.WebProfile(),
 	}
 	data := make(map[string]string)
 
@@ -31,8 +37,13 @@ func (service *RegisterCellphoneService) RegisterCellphone() (float64, []byte) {
 	data["password"] = hex.EncodeToString(h.Sum(nil))
 	data["captcha"] = service.Captcha
 	data["nickname"] = service.Nickname
+	if service.Countrycode != "" {
+		data["countrycode"] = service.Countrycode
+	} else {
+		data["countrycode"] = "86"
+	}
 
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/weapi/register/cellphone`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/weapi/register/cellphone`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
@@ -1,14 +1,23 @@
 package service
 
 import (
+	"context"
+
 	"github.com/go-musicfox/netease-music/util"
 )
 
 type RelatedAllVideoService struct {
 	ID string `json:"id" form:"id"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *RelatedAllVideoService) RelatedAllVideo() (float64, []byte) {
+	code, reBody, _ := service.RelatedAllVideoContext(context.Background())
+	return code, reBody
+}
+
+func (service *RelatedAllVideoService) RelatedAllVideoContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto: "weapi",
@@ -16,7 +25,7 @@ func (service *RelatedAllVideoService) RelatedAllVideo() (float64, []byte) {
 	data := make(map[string]string)
 	data["id"] = service.ID
 	data["type"] = "1"
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/weapi/cloudvideo/v1/allvideo/rcmd`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/weapi/cloudvideo/v1/allvideo/rcmd`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
@@ -1,6 +1,7 @@
 package service
 
 import (
+	"context"
 	"encoding/json"
 	"regexp"
 
@@ -9,9 +10,16 @@ import (
 
 type RelatedPlaylistService struct {
 	ID string `json:"id" form:"id"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *RelatedPlaylistService) RelatedPlaylist() (float64, []byte) {
+	code, reBody, _ := service.RelatedPlaylistContext(context.Background())
+	return code, reBody
+}
+
+func (service *RelatedPlaylistService) RelatedPlaylistContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto: "weapi",
@@ -19,7 +27,7 @@ func (service *RelatedPlaylistService) RelatedPlaylist() (float64, []byte) {
 	}
 	data := make(map[string]string)
 
-	code, reBody, _ := util.CreateRequest("GET", `https://music.163.com/playlist?id=`+service.ID, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "GET", `https://music.163.com/playlist?id=`+service.ID, data, options)
 
 	reg := regexp.MustCompile("<div class=\"cver u-cover u-cover-3\">[\\s\\S]*?<img src=\"([^\"]+)\">[\\s\\S]*?<a class=\"sname f-fs1 s-fc0\" href=\"([^\"]+)\"[^>]*>([^<]+?)<\\/a>[\\s\\S]*?<a class=\"nm nm f-thide s-fc3\" href=\"([^\"]+)\"[^>]*>([^<]+?)<\\/a>")
 	results := reg.FindAllSubmatch(reBody, -1)
@@ -47,5 +55,5 @@ func (service *RelatedPlaylistService) RelatedPlaylist() (float64, []byte) {
 	}
 
 	res, _ := json.Marshal(Results)
-	return code, res
+	return code, res, err
 }
@@ -1,6 +1,7 @@
 package service
 
 import (
+	"context"
 	"encoding/json"
 	"strconv"
 
@@ -15,6 +16,8 @@ type ReportService struct {
 	Time       int64  `json:"time" form:"time"`
 	Alg        string `json:"alg" form:"alg"`
 	EndType    string `json:"endType" form:"endType"` // playend：正常结束；interrupt：第三方APP打断： exception: 错误； ui: 用户切歌
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 // Playend 上报歌曲播放停止
@@ -24,6 +27,11 @@ type ReportService struct {
 //   - bodyBytes: 完整的响应体
 //   - err: 错误内容
 func (service *ReportService) Playend() (float64, []byte, error) {
+	code, bodyBytes, err := service.PlayendContext(context.Background())
+	return code, bodyBytes, legacyError(err)
+}
+
+func (service *ReportService) PlayendContext(ctx context.Context) (float64, []byte, error) {
 	if service.EndType == "" {
 		service.EndType = "playend"
 	}
@@ -68,10 +76,10 @@ func (service *ReportService) Playend() (float64, []byte, error) {
 	}
 
 	api := "https://clientlogusf.music.163.com/weapi/feedback/weblog"
-	cookiejar := util.GetGlobalCookieJar()
+	cookiejar := service.Client.CookieJar()
 	csrfToken := util.GetCsrfToken(cookiejar)
 	data["csrf_token"] = csrfToken
-	code, bodyBytes, err := util.CallWeapi(api+"?csrf_token="+csrfToken, data)
+	code, bodyBytes, err := service.Client.CallWeapiContext(ctx, api+"?csrf_token="+csrfToken, data)
 	if err != nil {
 		return code, bodyBytes, err
 	}
@@ -81,6 +89,11 @@ func (service *ReportService) Playend() (float64, []byte, error) {
 
 // Playstart 上报歌曲播放开始
 func (service *ReportService) Playstart() (float64, []byte, error) {
+	code, bodyBytes, err := service.PlaystartContext(context.Background())
+	return code, bodyBytes, legacyError(err)
+}
+
+func (service *ReportService) PlaystartContext(ctx context.Context) (float64, []byte, error) {
 	if service.Type == "" {
 		service.Type = "song"
 	}
@@ -113,10 +126,10 @@ func (service *ReportService) Playstart() (float64, []byte, error) {
 		data["logs"] = string(str)
 	}
 	api := "https://clientlogusf.music.163.com/weapi/feedback/weblog"
-	cookiejar := util.GetGlobalCookieJar()
+	cookiejar := service.Client.CookieJar()
 	csrfToken := util.GetCsrfToken(cookiejar)
 	data["csrf_token"] = csrfToken
-	code, bodyBytes, err := util.CallWeapi(api+"?csrf_token="+csrfToken, data)
+	code, bodyBytes, err := service.Client.CallWeapiContext(ctx, api+"?csrf_token="+csrfToken, data)
 	if err != nil {
 		return code, bodyBytes, err
 	}
@@ -3,16 +3,19 @@ package service
 import (
 	"testing"
 
-	"github.com/go-musicfox/netease-music/util"
+	"github.com/go-musicfox/netease-music/neteasetest"
 )
 
 func TestReportService(t *testing.T) {
-	// 为确保测试成功，请将登陆后的cookies以map类型传入
-	cookiesMap := map[string]string{}
-	// 添加 cookies 到全局 CookieJar
-	util.AddCookiesToJar(util.GetGlobalCookieJar(), cookiesMap, "https://music.163.com")
+	server := neteasetest.NewServer()
+	defer server.Close()
+
+	// 上报需要登录态，使用测试服务器的登录 Cookie
+	client := server.NewClient()
+	server.Login(client)
 
 	service := &ReportService{
+		Client:     client,
 		ID:         2084034562,
 		Type:       "song",
 		SourceType: "list",
@@ -1,7 +1,7 @@
 package service
 
 import (
-	"net/http"
+	"context"
 
 	"github.com/go-musicfox/netease-music/util"
 )
@@ -11,15 +11,20 @@ type ResourceLikeService struct {
 	ThreadId string `json:"threadId" form:"threadId"`
 	T        string `json:"t" form:"t"`
 	Type     string `json:"type" form:"type"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *ResourceLikeService) ResourceLike() (float64, []byte) {
+	code, reBody, _ := service.ResourceLikeContext(context.Background())
+	return code, reBody
+}
 
-	cookiesOS := &http.Cookie{Name: "os", Value: "pc"}
+func (service *ResourceLikeService) ResourceLikeContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto:  "weapi",
-		Cookies: []*http.Cookie{cookiesOS},
+		Profile: util.WebProfile(),
 	}
 	TYPE := make(map[string]string, 6)
 	TYPE["1"] = "R_MV_5_"
@@ -45,7 +50,7 @@ func (service *ResourceLikeService) ResourceLike() (float64, []byte) {
 	} else {
 		service.T = "unlike"
 	}
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/weapi/resource/`+service.T, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/weapi/resource/`+service.T, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
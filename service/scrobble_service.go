@@ -1,6 +1,7 @@
 package service
 
 import (
+	"context"
 	"encoding/json"
 
 	"github.com/go-musicfox/netease-music/util"
@@ -10,9 +11,16 @@ type ScrobbleService struct {
 	ID       string `json:"id" form:"id"`
 	Sourceid string `json:"sourceid" form:"sourceid"`
 	Time     int64  `json:"time" form:"time"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *ScrobbleService) Scrobble() (float64, []byte, error) {
+	code, bodyBytes, err := service.ScrobbleContext(context.Background())
+	return code, bodyBytes, legacyError(err)
+}
+
+func (service *ScrobbleService) ScrobbleContext(ctx context.Context) (float64, []byte, error) {
 
 	var logs = []map[string]interface{}{
 		{
@@ -36,10 +44,10 @@ func (service *ScrobbleService) Scrobble() (float64, []byte, error) {
 	}
 
 	api := "https://clientlogusf.music.163.com/weapi/feedback/weblog"
-	cookiejar := util.GetGlobalCookieJar()
+	cookiejar := service.Client.CookieJar()
 	csrfToken := util.GetCsrfToken(cookiejar)
 	data["csrf_token"] = csrfToken
-	code, bodyBytes, err := util.CallWeapi(api+"?csrf_token="+csrfToken, data)
+	code, bodyBytes, err := service.Client.CallWeapiContext(ctx, api+"?csrf_token="+csrfToken, data)
 	if err != nil {
 		return code, bodyBytes, err
 	}
@@ -1,13 +1,21 @@
 package service
 
 import (
+	"context"
+
 	"github.com/go-musicfox/netease-music/util"
 )
 
 type SearchDefaultService struct {
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *SearchDefaultService) SearchDefault() (float64, []byte) {
+	code, reBody, _ := service.SearchDefaultContext(context.Background())
+	return code, reBody
+}
+
+func (service *SearchDefaultService) SearchDefaultContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto: "eapi",
@@ -15,7 +23,7 @@ func (service *SearchDefaultService) SearchDefault() (float64, []byte) {
 	}
 	data := make(map[string]string)
 
-	code, reBody, _ := util.CreateRequest("POST", `http://interface3.music.163.com/eapi/search/defaultkeyword/get`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `http://interface3.music.163.com/eapi/search/defaultkeyword/get`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
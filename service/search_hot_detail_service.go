@@ -1,20 +1,28 @@
 package service
 
 import (
+	"context"
+
 	"github.com/go-musicfox/netease-music/util"
 )
 
 type SearchHotDetailService struct {
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *SearchHotDetailService) SearchHotDetail() (float64, []byte) {
+	code, reBody, _ := service.SearchHotDetailContext(context.Background())
+	return code, reBody
+}
+
+func (service *SearchHotDetailService) SearchHotDetailContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto: "weapi",
 	}
 	data := make(map[string]string)
 
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/weapi/hotsearchlist/get`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/weapi/hotsearchlist/get`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
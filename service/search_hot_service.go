@@ -1,13 +1,21 @@
 package service
 
 import (
+	"context"
+
 	"github.com/go-musicfox/netease-music/util"
 )
 
 type SearchHotService struct {
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *SearchHotService) SearchHot() (float64, []byte) {
+	code, reBody, _ := service.SearchHotContext(context.Background())
+	return code, reBody
+}
+
+func (service *SearchHotService) SearchHotContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto: "weapi",
@@ -16,7 +24,7 @@ func (service *SearchHotService) SearchHot() (float64, []byte) {
 	data := make(map[string]string)
 	data["type"] = "1111"
 
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/weapi/search/hot`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/weapi/search/hot`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
@@ -1,15 +1,24 @@
 package service
 
 import (
+	"context"
+
 	"github.com/go-musicfox/netease-music/util"
 )
 
 type SearchMultimatchService struct {
 	Type string `json:"type" form:"type"`
 	S    string `json:"keywords" form:"keywords"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *SearchMultimatchService) SearchMultimatch() (float64, []byte) {
+	code, reBody, _ := service.SearchMultimatchContext(context.Background())
+	return code, reBody
+}
+
+func (service *SearchMultimatchService) SearchMultimatchContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto: "weapi",
@@ -20,7 +29,7 @@ func (service *SearchMultimatchService) SearchMultimatch() (float64, []byte) {
 	}
 	data["type"] = service.Type
 	data["s"] = service.S
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/weapi/search/suggest/multimatch`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/weapi/search/suggest/multimatch`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
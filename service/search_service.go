@@ -1,6 +1,8 @@
 package service
 
 import (
+	"context"
+
 	"github.com/go-musicfox/netease-music/util"
 )
 
@@ -9,9 +11,16 @@ type SearchService struct {
 	Type   string `json:"type" form:"type"`
 	Limit  string `json:"limit" form:"limit"`
 	Offset string `json:"offset" form:"offset"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *SearchService) Search() (float64, []byte) {
+	code, reBody, _ := service.SearchContext(context.Background())
+	return code, reBody
+}
+
+func (service *SearchService) SearchContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto: "weapi",
@@ -33,14 +42,14 @@ func (service *SearchService) Search() (float64, []byte) {
 	if service.Type == "2000" {
 		data["keyword"] = service.S
 		data["scene"] = "normal"
-		code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/api/search/voice/get`, data, options)
-		return code, reBody
+		code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/api/search/voice/get`, data, options)
+		return code, reBody, err
 	}
 
 	data["type"] = service.Type
 	data["s"] = service.S
 
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/api/cloudsearch/pc`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/api/cloudsearch/pc`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
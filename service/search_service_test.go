@@ -3,12 +3,18 @@ package service
 import (
 	"fmt"
 	"testing"
+
+	"github.com/go-musicfox/netease-music/neteasetest"
 )
 
 func TestSearchService_Search(t *testing.T) {
+	server := neteasetest.NewServer()
+	defer server.Close()
+
 	service := &SearchService{
-		S:    "测试",
-		Type: "1",
+		Client: server.NewClient(),
+		S:      "测试",
+		Type:   "1",
 	}
 	code, resp := service.Search()
 	fmt.Println(code, string(resp))
@@ -16,3 +22,18 @@ func TestSearchService_Search(t *testing.T) {
 		t.Errorf("code error: %f", code)
 	}
 }
+
+func TestSearchDefaultService_SearchDefault(t *testing.T) {
+	server := neteasetest.NewServer()
+	defer server.Close()
+	server.HandleJSON("/api/search/defaultkeyword/get", `{"code":200,"data":{"showKeyword":"测试"}}`)
+
+	service := &SearchDefaultService{Client: server.NewClient()}
+	code, resp := service.SearchDefault()
+	if code != 200 {
+		t.Fatalf("code error: %f, %s", code, resp)
+	}
+	if server.LastRequest("/api/search/defaultkeyword/get") == nil {
+		t.Error("request to interface3.music.163.com not sent to the fake server")
+	}
+}
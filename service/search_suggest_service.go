@@ -1,15 +1,24 @@
 package service
 
 import (
+	"context"
+
 	"github.com/go-musicfox/netease-music/util"
 )
 
 type SearchSuggestService struct {
 	S    string `json:"keywords" form:"keywords"`
 	Type string `json:"type" form:"type"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *SearchSuggestService) SearchSuggest() (float64, []byte) {
+	code, reBody, _ := service.SearchSuggestContext(context.Background())
+	return code, reBody
+}
+
+func (service *SearchSuggestService) SearchSuggestContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto: "weapi",
@@ -22,7 +31,7 @@ func (service *SearchSuggestService) SearchSuggest() (float64, []byte) {
 		service.Type = "web"
 	}
 	data["s"] = service.S
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/weapi/search/suggest/`+service.Type, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/weapi/search/suggest/`+service.Type, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
@@ -1,7 +1,7 @@
 package service
 
 import (
-	"net/http"
+	"context"
 
 	"github.com/go-musicfox/netease-music/util"
 )
@@ -10,22 +10,27 @@ type SendTextService struct {
 	ID      string `json:"playlist" form:"playlist"`
 	Msg     string `json:"msg" form:"msg"`
 	UserIds string `json:"user_ids" form:"user_ids"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *SendTextService) SendText() (float64, []byte) {
+	code, reBody, _ := service.SendTextContext(context.Background())
+	return code, reBody
+}
 
-	cookiesOS := &http.Cookie{Name: "os", Value: "pc"}
+func (service *SendTextService) SendTextContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto:  "weapi",
-		Cookies: []*http.Cookie{cookiesOS},
+		Profile: util.WebProfile(),
 	}
 	data := make(map[string]string)
 	data["id"] = service.ID
 	data["type"] = "text"
 	data["msg"] = service.Msg
 	data["userIds"] = "[" + service.UserIds + "]"
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/weapi/msg/private/send`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/weapi/msg/private/send`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
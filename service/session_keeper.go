@@ -0,0 +1,186 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"github.com/buger/jsonparser"
+	"github.com/go-musicfox/netease-music/util"
+)
+
+// SessionEventType 会话事件的类型
+type SessionEventType int
+
+const (
+	// SessionRefreshed 刷新登录成功，且通过 UserAccountService 确认仍处于登录状态
+	SessionRefreshed SessionEventType = iota
+	// SessionRefreshFailed 网络错误等原因未能刷新，稍后会重试
+	SessionRefreshFailed
+	// SessionInvalid 登录已失效，需要重新登录，SessionKeeper.Run 随之返回
+	SessionInvalid
+)
+
+func (t SessionEventType) String() string {
+	switch t {
+	case SessionRefreshed:
+		return "refreshed"
+	case SessionRefreshFailed:
+		return "refresh_failed"
+	case SessionInvalid:
+		return "invalid"
+	}
+	return "unknown"
+}
+
+// SessionEvent SessionKeeper 每次刷新登录的结果
+type SessionEvent struct {
+	Type SessionEventType
+	Time time.Time
+	// UserID 校验登录时得到的用户ID，登录失效时为0
+	UserID int64
+	// ExpiresAt MUSIC_U 的过期时间，未知时为零值
+	ExpiresAt time.Time
+	// Err SessionRefreshFailed、SessionInvalid 的原因
+	Err error
+}
+
+// SessionKeeper 在后台定期刷新登录，避免长时间运行时 MUSIC_U 过期导致悄悄退出登录
+//
+// 每次刷新调用 LoginRefreshService，再通过 UserAccountService 确认登录仍然有效。
+// 刷新间隔为 Interval 加上随机抖动，MUSIC_U 即将过期（见 util.Client.LoginExpires）时会提前刷新，
+// 提前刷新后过期时间没有延后时按 RetryInterval 重试
+type SessionKeeper struct {
+	Client *util.Client
+	// Interval 两次刷新的间隔，默认 24 小时
+	Interval time.Duration
+	// Jitter 刷新时间的随机抖动范围（正负），默认为 Interval 的 1/10
+	Jitter time.Duration
+	// RefreshBefore MUSIC_U 剩余有效期不足该时长时提前刷新，默认 3 天
+	RefreshBefore time.Duration
+	// RetryInterval 刷新失败后的重试间隔，默认 5 分钟
+	RetryInterval time.Duration
+	// OnEvent 每次刷新后调用，可为 nil
+	OnEvent func(SessionEvent)
+
+	mu          sync.Mutex
+	lastRefresh time.Time
+}
+
+// Run 立即刷新一次登录，之后按计划刷新，直到 ctx 结束或登录失效
+//
+// 登录失效时返回 util.ErrNotLoggedIn，ctx 结束时返回 ctx.Err()
+func (k *SessionKeeper) Run(ctx context.Context) error {
+	var wait time.Duration
+	for {
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+		err := k.Refresh(ctx)
+		switch {
+		case errors.Is(err, util.ErrNotLoggedIn):
+			return err
+		case ctx.Err() != nil:
+			return ctx.Err()
+		case err != nil:
+			wait = k.retryInterval()
+		default:
+			wait = k.NextRefresh().Sub(time.Now())
+		}
+	}
+}
+
+// Refresh 立即刷新一次登录并校验，登录失效时返回 util.ErrNotLoggedIn
+func (k *SessionKeeper) Refresh(ctx context.Context) error {
+	_, _, refreshErr := (&LoginRefreshService{Client: k.Client}).LoginRefreshContext(ctx)
+	if errors.Is(refreshErr, util.ErrTransport) {
+		k.emit(SessionEvent{Type: SessionRefreshFailed, Err: refreshErr})
+		return refreshErr
+	}
+
+	// 刷新接口在未登录时返回 301，其他错误是否仍处于登录状态以账号信息为准
+	_, body, err := (&UserAccountService{Client: k.Client}).AccountInfoContext(ctx)
+	if err != nil && !errors.Is(err, util.ErrNotLoggedIn) {
+		k.emit(SessionEvent{Type: SessionRefreshFailed, Err: err})
+		return err
+	}
+	userID, _ := jsonparser.GetInt(body, "account", "id")
+	if userID <= 0 {
+		k.emit(SessionEvent{Type: SessionInvalid, Err: util.ErrNotLoggedIn})
+		return util.ErrNotLoggedIn
+	}
+	if refreshErr != nil {
+		k.emit(SessionEvent{Type: SessionRefreshFailed, UserID: userID, Err: refreshErr})
+		return refreshErr
+	}
+
+	k.mu.Lock()
+	k.lastRefresh = time.Now()
+	k.mu.Unlock()
+	k.emit(SessionEvent{Type: SessionRefreshed, UserID: userID})
+	return nil
+}
+
+// LastRefresh 返回最近一次成功刷新的时间，尚未刷新时返回零值
+func (k *SessionKeeper) LastRefresh() time.Time {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	return k.lastRefresh
+}
+
+// NextRefresh 返回计划的下一次刷新时间，每次调用的随机抖动不同
+func (k *SessionKeeper) NextRefresh() time.Time {
+	last := k.LastRefresh()
+	if last.IsZero() {
+		return time.Now()
+	}
+	interval, jitter := k.Interval, k.Jitter
+	if interval <= 0 {
+		interval = 24 * time.Hour
+	}
+	if jitter <= 0 {
+		jitter = interval / 10
+	}
+	next := last.Add(interval)
+	if jitter > 0 {
+		next = next.Add(time.Duration(rand.Int64N(int64(2*jitter))) - jitter)
+	}
+	if expires := k.Client.LoginExpires(); !expires.IsZero() {
+		before := k.RefreshBefore
+		if before <= 0 {
+			before = 3 * 24 * time.Hour
+		}
+		early := expires.Add(-before)
+		if !early.After(last) {
+			// 已在提前刷新的时间之后刷新过，但过期时间没有延后（刷新未重新下发 MUSIC_U 或剩余有效期本就不足），
+			// 按 RetryInterval 再次尝试，避免反复立即刷新
+			early = last.Add(k.retryInterval())
+		}
+		if early.Before(next) {
+			next = early
+		}
+	}
+	return next
+}
+
+func (k *SessionKeeper) retryInterval() time.Duration {
+	if k.RetryInterval > 0 {
+		return k.RetryInterval
+	}
+	return 5 * time.Minute
+}
+
+func (k *SessionKeeper) emit(e SessionEvent) {
+	if k.OnEvent == nil {
+		return
+	}
+	e.Time = time.Now()
+	e.ExpiresAt = k.Client.LoginExpires()
+	k.OnEvent(e)
+}
@@ -0,0 +1,134 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-musicfox/netease-music/neteasetest"
+	"github.com/go-musicfox/netease-music/util"
+)
+
+func TestSessionKeeper_Run(t *testing.T) {
+	server := neteasetest.NewServer()
+	defer server.Close()
+	client := server.NewClient()
+	server.Login(client)
+
+	var (
+		mu     sync.Mutex
+		events []SessionEvent
+	)
+	keeper := &SessionKeeper{
+		Client:   client,
+		Interval: 20 * time.Millisecond,
+		OnEvent: func(e SessionEvent) {
+			mu.Lock()
+			defer mu.Unlock()
+			events = append(events, e)
+			if len(events) == 2 {
+				// 第三次刷新时账号已退出登录
+				server.HandleJSON("/api/nuser/account/get", `{"code":200,"account":null,"profile":null}`)
+			}
+		},
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := keeper.Run(ctx); !errors.Is(err, util.ErrNotLoggedIn) {
+		t.Fatalf("Run returned %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(events) != 3 {
+		t.Fatalf("events: %+v", events)
+	}
+	for _, e := range events[:2] {
+		if e.Type != SessionRefreshed || e.UserID != 1 || e.ExpiresAt.Before(time.Now().Add(14*24*time.Hour)) {
+			t.Errorf("unexpected event: %+v", e)
+		}
+	}
+	if events[2].Type != SessionInvalid || !errors.Is(events[2].Err, util.ErrNotLoggedIn) {
+		t.Errorf("unexpected event: %+v", events[2])
+	}
+	if keeper.LastRefresh().IsZero() {
+		t.Error("last refresh not recorded")
+	}
+}
+
+func TestSessionKeeper_NextRefresh(t *testing.T) {
+	server := neteasetest.NewServer()
+	defer server.Close()
+	client := server.NewClient()
+	server.Login(client)
+
+	keeper := &SessionKeeper{Client: client, Interval: 30 * 24 * time.Hour, Jitter: time.Hour}
+	if next := keeper.NextRefresh(); next.After(time.Now()) {
+		t.Fatalf("first refresh scheduled at %v", next)
+	}
+	if err := keeper.Refresh(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	// MUSIC_U 15 天后过期，应在过期前 3 天刷新而不是 30 天后
+	want := client.LoginExpires().Add(-3 * 24 * time.Hour)
+	if next := keeper.NextRefresh(); !next.Equal(want) {
+		t.Fatalf("next refresh %v, want %v", next, want)
+	}
+
+	keeper.Interval = time.Hour
+	for i := 0; i < 10; i++ {
+		next := keeper.NextRefresh().Sub(keeper.LastRefresh())
+		if next < 0 || next > 2*time.Hour {
+			t.Fatalf("jitter out of range: %v", next)
+		}
+	}
+}
+
+func TestSessionKeeper_ExpiringSoon(t *testing.T) {
+	server := neteasetest.NewServer()
+	defer server.Close()
+	client := server.NewClient()
+	server.Login(client)
+
+	// MUSIC_U 15 天后过期，剩余有效期始终不足 RefreshBefore，刷新也不会延后过期时间
+	var refreshes int
+	keeper := &SessionKeeper{
+		Client:        client,
+		RefreshBefore: 30 * 24 * time.Hour,
+		RetryInterval: 50 * time.Millisecond,
+		OnEvent: func(e SessionEvent) {
+			if e.Type == SessionRefreshed {
+				refreshes++
+			}
+		},
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Millisecond)
+	defer cancel()
+	if err := keeper.Run(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Run returned %v", err)
+	}
+	if refreshes < 1 || refreshes > 3 {
+		t.Fatalf("refreshed %d times", refreshes)
+	}
+	if next, want := keeper.NextRefresh(), keeper.LastRefresh().Add(keeper.RetryInterval); !next.Equal(want) {
+		t.Fatalf("next refresh %v, want %v", next, want)
+	}
+}
+
+func TestSessionKeeper_RefreshFailed(t *testing.T) {
+	server := neteasetest.NewServer()
+	client := server.NewClient()
+	server.Login(client)
+	server.Close()
+
+	var got []SessionEvent
+	keeper := &SessionKeeper{Client: client, OnEvent: func(e SessionEvent) { got = append(got, e) }}
+	if err := keeper.Refresh(context.Background()); !errors.Is(err, util.ErrTransport) {
+		t.Fatalf("Refresh returned %v", err)
+	}
+	if len(got) != 1 || got[0].Type != SessionRefreshFailed {
+		t.Fatalf("events: %+v", got)
+	}
+}
@@ -1,19 +1,27 @@
 package service
 
 import (
+	"context"
+
 	"github.com/go-musicfox/netease-music/util"
 )
 
 type SettingService struct {
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *SettingService) Setting() (float64, []byte) {
+	code, reBody, _ := service.SettingContext(context.Background())
+	return code, reBody
+}
+
+func (service *SettingService) SettingContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto: "weapi",
 	}
 	data := make(map[string]string)
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/api/user/setting`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/api/user/setting`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
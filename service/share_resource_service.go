@@ -1,6 +1,8 @@
 package service
 
 import (
+	"context"
+
 	"github.com/go-musicfox/netease-music/util"
 )
 
@@ -8,9 +10,16 @@ type ShareResourceService struct {
 	Id   string `json:"id" form:"id"`
 	Msg  string `json:"msg" form:"msg"`
 	Type string `json:"type" form:"type"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *ShareResourceService) ShareResource() (float64, []byte) {
+	code, reBody, _ := service.ShareResourceContext(context.Background())
+	return code, reBody
+}
+
+func (service *ShareResourceService) ShareResourceContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto: "weapi",
@@ -24,7 +33,7 @@ func (service *ShareResourceService) ShareResource() (float64, []byte) {
 	} else {
 		data["type"] = service.Type
 	}
-	code, reBody, _ := util.CreateRequest("POST", `http://music.163.com/weapi/share/friends/resource`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `http://music.163.com/weapi/share/friends/resource`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
@@ -1,14 +1,23 @@
 package service
 
 import (
+	"context"
+
 	"github.com/go-musicfox/netease-music/util"
 )
 
 type SimiArtistService struct {
 	ID string `json:"id" form:"id"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *SimiArtistService) SimiArtist() (float64, []byte) {
+	code, reBody, _ := service.SimiArtistContext(context.Background())
+	return code, reBody
+}
+
+func (service *SimiArtistService) SimiArtistContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto: "weapi",
@@ -16,7 +25,7 @@ func (service *SimiArtistService) SimiArtist() (float64, []byte) {
 	data := make(map[string]string)
 	data["id"] = service.ID
 
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/weapi/discovery/simiArtist`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/weapi/discovery/simiArtist`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
@@ -1,6 +1,8 @@
 package service
 
 import (
+	"context"
+
 	"github.com/go-musicfox/netease-music/util"
 )
 
@@ -8,16 +10,23 @@ type SimiMvService struct {
 	ID     string `json:"mvid" form:"mvid"`
 	Limit  string `json:"limit" form:"limit"`
 	Offset string `json:"offset" form:"offset"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *SimiMvService) SimiMv() (float64, []byte) {
+	code, reBody, _ := service.SimiMvContext(context.Background())
+	return code, reBody
+}
+
+func (service *SimiMvService) SimiMvContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto: "weapi",
 	}
 	data := make(map[string]string)
 	data["mvid"] = service.ID
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/weapi/discovery/simiMV`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/weapi/discovery/simiMV`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
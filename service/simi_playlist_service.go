@@ -1,7 +1,7 @@
 package service
 
 import (
-	"net/http"
+	"context"
 
 	"github.com/go-musicfox/netease-music/util"
 )
@@ -10,15 +10,20 @@ type SimiPlaylistService struct {
 	ID     string `json:"id" form:"id"`
 	Limit  string `json:"limit" form:"limit"`
 	Offset string `json:"offset" form:"offset"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *SimiPlaylistService) SimiPlaylist() (float64, []byte) {
+	code, reBody, _ := service.SimiPlaylistContext(context.Background())
+	return code, reBody
+}
 
-	cookiesOS := &http.Cookie{Name: "os", Value: "pc"}
+func (service *SimiPlaylistService) SimiPlaylistContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto:  "weapi",
-		Cookies: []*http.Cookie{cookiesOS},
+		Profile: util.WebProfile(),
 	}
 	data := make(map[string]string)
 	data["songid"] = service.ID
@@ -32,7 +37,7 @@ func (service *SimiPlaylistService) SimiPlaylist() (float64, []byte) {
 	} else {
 		data["offset"] = service.Offset
 	}
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/weapi/discovery/simiPlaylist`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/weapi/discovery/simiPlaylist`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
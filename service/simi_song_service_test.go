@@ -3,12 +3,18 @@ package service
 import (
 	"fmt"
 	"testing"
+
+	"github.com/go-musicfox/netease-music/neteasetest"
 )
 
 func TestSimiSongService_SimiSong(t *testing.T) {
+	server := neteasetest.NewServer()
+	defer server.Close()
+
 	service := &SimiSongService{
-		ID:    "405998841",
-		Limit: "999",
+		Client: server.NewClient(),
+		ID:     "405998841",
+		Limit:  "999",
 	}
 	code, resp := service.SimiSong()
 	fmt.Println(code, string(resp))
@@ -1,6 +1,8 @@
 package service
 
 import (
+	"context"
+
 	"github.com/go-musicfox/netease-music/util"
 )
 
@@ -8,9 +10,16 @@ type SimiUserService struct {
 	ID     string `json:"id" form:"id"`
 	Limit  string `json:"limit" form:"limit"`
 	Offset string `json:"offset" form:"offset"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *SimiUserService) SimiUser() (float64, []byte) {
+	code, reBody, _ := service.SimiUserContext(context.Background())
+	return code, reBody
+}
+
+func (service *SimiUserService) SimiUserContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto: "weapi",
@@ -28,7 +37,7 @@ func (service *SimiUserService) SimiUser() (float64, []byte) {
 		data["offset"] = service.Offset
 	}
 
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/weapi/discovery/simiUser`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/weapi/discovery/simiUser`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
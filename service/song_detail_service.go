@@ -1,8 +1,8 @@
 package service
 
 import (
+	"context"
 	"encoding/json"
-	"net/http"
 	"strings"
 
 	"github.com/go-musicfox/netease-music/util"
@@ -10,15 +10,20 @@ import (
 
 type SongDetailService struct {
 	Ids string `json:"ids" form:"ids"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *SongDetailService) SongDetail() (float64, []byte) {
+	code, reBody, _ := service.SongDetailContext(context.Background())
+	return code, reBody
+}
 
-	cookiesOS := &http.Cookie{Name: "os", Value: "pc"}
+func (service *SongDetailService) SongDetailContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto:  "weapi",
-		Cookies: []*http.Cookie{cookiesOS},
+		Profile: util.WebProfile(),
 	}
 
 	type IDS struct {
@@ -36,7 +41,7 @@ func (service *SongDetailService) SongDetail() (float64, []byte) {
 	data := make(map[string]string)
 	data["c"] = string(sidsJsonByte)
 	data["ids"] = "[" + service.Ids + "]"
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/weapi/v3/song/detail`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/weapi/v3/song/detail`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
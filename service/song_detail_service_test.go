@@ -0,0 +1,33 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-musicfox/netease-music/neteasetest"
+	"github.com/go-musicfox/netease-music/util"
+)
+
+func TestSongDetailService_Offline(t *testing.T) {
+	server := neteasetest.NewServer()
+
+	client := server.NewClient()
+	client.Cache = util.NewCache(nil)
+	client.Cache.Offline = true
+	service := &SongDetailService{Client: client, Ids: "405998841"}
+
+	var info util.ResponseInfo
+	code, online, err := service.SongDetailContext(util.WithResponseInfo(context.Background(), &info))
+	if err != nil || code != 200 || info.FromCache {
+		t.Fatalf("code %f, err %v, info %+v", code, err, &info)
+	}
+
+	server.Close()
+	code, offline, err := service.SongDetailContext(util.WithResponseInfo(context.Background(), &info))
+	if err != nil || code != 200 || string(offline) != string(online) {
+		t.Fatalf("code %f, err %v, body %s", code, err, offline)
+	}
+	if !info.FromCache || !info.Stale || info.StoredAt.IsZero() {
+		t.Errorf("stale response not reported: %+v", &info)
+	}
+}
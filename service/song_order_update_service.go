@@ -1,15 +1,24 @@
 package service
 
 import (
+	"context"
+
 	"github.com/go-musicfox/netease-music/util"
 )
 
 type SongOrderUpdateService struct {
 	Pid string `json:"pid" form:"pid"`
 	Ids string `json:"ids" form:"ids"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *SongOrderUpdateService) SongOrderUpdate() (float64, []byte) {
+	code, reBody, _ := service.SongOrderUpdateContext(context.Background())
+	return code, reBody
+}
+
+func (service *SongOrderUpdateService) SongOrderUpdateContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto: "weapi",
@@ -19,7 +28,7 @@ func (service *SongOrderUpdateService) SongOrderUpdate() (float64, []byte) {
 	data["pid"] = service.Pid
 	data["trackIds"] = service.Ids
 	data["op"] = "update"
-	code, reBody, _ := util.CreateRequest("POST", `http://interface.music.163.com/api/playlist/manipulate/tracks`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `http://interface.music.163.com/api/playlist/manipulate/tracks`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
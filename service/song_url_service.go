@@ -1,7 +1,7 @@
 package service
 
 import (
-	"net/http"
+	"context"
 
 	"github.com/go-musicfox/netease-music/util"
 )
@@ -10,15 +10,20 @@ type SongUrlService struct {
 	ID      string `json:"id" form:"id"`
 	Br      string `json:"br" form:"br"`
 	SkipUNM bool
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *SongUrlService) SongUrl() (float64, []byte) {
+	code, reBody, _ := service.SongUrlContext(context.Background())
+	return code, reBody
+}
 
-	cookiesOS := &http.Cookie{Name: "os", Value: "pc"}
+func (service *SongUrlService) SongUrlContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto:  "linuxapi",
-		Cookies: []*http.Cookie{cookiesOS},
+		Profile: util.WebProfile(),
 		SkipUNM: service.SkipUNM,
 	}
 	data := make(map[string]string)
@@ -28,7 +33,7 @@ func (service *SongUrlService) SongUrl() (float64, []byte) {
 	}
 	data["br"] = service.Br
 
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/api/song/enhance/player/url`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/api/song/enhance/player/url`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
@@ -4,13 +4,18 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/go-musicfox/netease-music/neteasetest"
 	"github.com/go-musicfox/netease-music/util"
 )
 
 func TestSongUrlService_SongUrl(t *testing.T) {
+	server := neteasetest.NewServer()
+	defer server.Close()
+
 	service := &SongUrlService{
-		ID: "1962165890",
-		Br: "999000",
+		Client: server.NewClient(),
+		ID:     "1962165890",
+		Br:     "999000",
 	}
 	code, resp := service.SongUrl()
 	fmt.Println(code, string(resp))
@@ -20,11 +25,15 @@ func TestSongUrlService_SongUrl(t *testing.T) {
 }
 
 func TestSongUrlService_SongUrlWithUNM(t *testing.T) {
-	util.UNMSwitch = true
-	util.Sources = []string{"kuwo"}
+	server := neteasetest.NewServer()
+	defer server.Close()
+
+	client := server.NewClient()
+	client.UNM = &util.UNMConfig{Enable: true, Sources: []string{"kuwo"}}
 	service := &SongUrlService{
-		ID: "1962165890",
-		Br: "320000",
+		Client: client,
+		ID:     "1962165890",
+		Br:     "320000",
 	}
 	code, resp := service.SongUrl()
 	fmt.Println(code, string(resp))
@@ -1,6 +1,8 @@
 package service
 
 import (
+	"context"
+
 	"github.com/go-musicfox/netease-music/util"
 )
 
@@ -31,9 +33,16 @@ type SongUrlV1Service struct {
 	Level      SongQualityLevel `json:"level" form:"level"` // standard, exhigh, lossless, hires, jyeffect(高清环绕声), sky(沉浸环绕声), jymaster(超清母带) 进行音质判断
 	EncodeType string           `json:"encodeType" form:"encodeType"`
 	SkipUNM    bool
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *SongUrlV1Service) SongUrl() (float64, []byte, error) {
+	code, bodyBytes, err := service.SongUrlContext(context.Background())
+	return code, bodyBytes, legacyError(err)
+}
+
+func (service *SongUrlV1Service) SongUrlContext(ctx context.Context) (float64, []byte, error) {
 	data := make(map[string]interface{})
 	data["ids"] = "[" + service.ID + "]"
 	if service.Level == "" {
@@ -49,6 +58,6 @@ func (service *SongUrlV1Service) SongUrl() (float64, []byte, error) {
 	data["encodeType"] = service.EncodeType
 
 	api := "https://music.163.com/weapi/song/enhance/player/url/v1"
-	code, bodyBytes, err := util.CallWeapi(api, data)
+	code, bodyBytes, err := service.Client.CallWeapiContext(ctx, api, data)
 	return code, bodyBytes, err
 }
@@ -3,12 +3,18 @@ package service
 import (
 	"fmt"
 	"testing"
+
+	"github.com/go-musicfox/netease-music/neteasetest"
 )
 
 func TestSongUrlV1Service_SongUrl(t *testing.T) {
+	server := neteasetest.NewServer()
+	defer server.Close()
+
 	service := &SongUrlV1Service{
-		ID:    "405998841",
-		Level: "lossless",
+		Client: server.NewClient(),
+		ID:     "405998841",
+		Level:  "lossless",
 	}
 	code, resp, _ := service.SongUrl()
 	fmt.Println(code, string(resp))
@@ -1,7 +1,7 @@
 package service
 
 import (
-	"net/http"
+	"context"
 	"strconv"
 	"time"
 
@@ -15,15 +15,20 @@ type TopAlbumService struct {
 	Type   string `json:"type" form:"type"`
 	Year   string `json:"year" form:"year"`
 	Month  string `json:"month" form:"month"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *TopAlbumService) TopAlbum() (float64, []byte) {
+	code, reBody, _ := service.TopAlbumContext(context.Background())
+	return code, reBody
+}
 
-	cookiesOS := &http.Cookie{Name: "os", Value: "pc"}
+func (service *TopAlbumService) TopAlbumContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto:  "weapi",
-		Cookies: []*http.Cookie{cookiesOS},
+		Profile: util.WebProfile(),
 	}
 	data := make(map[string]string)
 
@@ -54,7 +59,7 @@ func (service *TopAlbumService) TopAlbum() (float64, []byte) {
 	data["total"] = "true"
 	data["rcmd"] = "false"
 
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/api/discovery/new/albums/area`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/api/discovery/new/albums/area`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
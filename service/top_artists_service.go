@@ -1,15 +1,24 @@
 package service
 
 import (
+	"context"
+
 	"github.com/go-musicfox/netease-music/util"
 )
 
 type TopArtistsService struct {
 	Limit  string `json:"limit" form:"limit"`
 	Offset string `json:"offset" form:"offset"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *TopArtistsService) TopArtists() (float64, []byte) {
+	code, reBody, _ := service.TopArtistsContext(context.Background())
+	return code, reBody
+}
+
+func (service *TopArtistsService) TopArtistsContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto: "weapi",
@@ -27,7 +36,7 @@ func (service *TopArtistsService) TopArtists() (float64, []byte) {
 		data["offset"] = service.Offset
 	}
 	data["order"] = "true"
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/weapi/artist/top`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/weapi/artist/top`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
@@ -1,6 +1,8 @@
 package service
 
 import (
+	"context"
+
 	"github.com/go-musicfox/netease-music/util"
 )
 
@@ -8,9 +10,16 @@ type TopPlaylistHighqualityService struct {
 	Limit    string `json:"limit" form:"limit"`
 	Cat      string `json:"cat" form:"cat"`
 	LastTime string `json:"lasttime" form:"lasttime"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *TopPlaylistHighqualityService) TopPlaylistHighquality() (float64, []byte) {
+	code, reBody, _ := service.TopPlaylistHighqualityContext(context.Background())
+	return code, reBody
+}
+
+func (service *TopPlaylistHighqualityService) TopPlaylistHighqualityContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto: "weapi",
@@ -31,7 +40,7 @@ func (service *TopPlaylistHighqualityService) TopPlaylistHighquality() (float64,
 	data["total"] = "true"
 	data["cat"] = service.Cat
 
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/weapi/playlist/highquality/list`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/weapi/playlist/highquality/list`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
@@ -1,6 +1,8 @@
 package service
 
 import (
+	"context"
+
 	"github.com/go-musicfox/netease-music/util"
 )
 
@@ -9,9 +11,16 @@ type TopPlaylistService struct {
 	Cat    string `json:"cat" form:"cat"`
 	Order  string `json:"order" form:"order"`
 	Offset string `json:"offset" form:"offset"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *TopPlaylistService) TopPlaylist() (float64, []byte) {
+	code, reBody, _ := service.TopPlaylistContext(context.Background())
+	return code, reBody
+}
+
+func (service *TopPlaylistService) TopPlaylistContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto: "weapi",
@@ -36,7 +45,7 @@ func (service *TopPlaylistService) TopPlaylist() (float64, []byte) {
 	data["hot"] = service.Order
 	data["cat"] = service.Cat
 
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/weapi/playlist/list`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/weapi/playlist/list`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
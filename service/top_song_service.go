@@ -1,14 +1,23 @@
 package service
 
 import (
+	"context"
+
 	"github.com/go-musicfox/netease-music/util"
 )
 
 type TopSongService struct {
 	AreaId string `json:"type" form:"type"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *TopSongService) TopSong() (float64, []byte) {
+	code, reBody, _ := service.TopSongContext(context.Background())
+	return code, reBody
+}
+
+func (service *TopSongService) TopSongContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto: "weapi",
@@ -19,7 +28,7 @@ func (service *TopSongService) TopSong() (float64, []byte) {
 	}
 	data["areaId"] = service.AreaId
 	data["total"] = "true"
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/weapi/v1/discovery/new/songs`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/weapi/v1/discovery/new/songs`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
@@ -1,20 +1,28 @@
 package service
 
 import (
+	"context"
+
 	"github.com/go-musicfox/netease-music/util"
 )
 
 type ToplistDetailService struct {
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *ToplistDetailService) ToplistDetail() (float64, []byte) {
+	code, reBody, _ := service.ToplistDetailContext(context.Background())
+	return code, reBody
+}
+
+func (service *ToplistDetailService) ToplistDetailContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto: "weapi",
 	}
 	data := make(map[string]string)
 
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/weapi/toplist/detail`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/weapi/toplist/detail`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
@@ -1,20 +1,28 @@
 package service
 
 import (
+	"context"
+
 	"github.com/go-musicfox/netease-music/util"
 )
 
 type ToplistService struct {
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *ToplistService) Toplist() (float64, []byte) {
+	code, reBody, _ := service.ToplistContext(context.Background())
+	return code, reBody
+}
+
+func (service *ToplistService) ToplistContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto: "linuxapi",
 	}
 	data := make(map[string]string)
 
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/api/toplist`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/api/toplist`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
@@ -1,19 +1,27 @@
 package service
 
 import (
+	"context"
+
 	"github.com/go-musicfox/netease-music/util"
 )
 
 type UserAccountService struct {
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *UserAccountService) AccountInfo() (float64, []byte) {
+	code, reBody, _ := service.AccountInfoContext(context.Background())
+	return code, reBody
+}
+
+func (service *UserAccountService) AccountInfoContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto: "weapi",
 	}
 	data := make(map[string]string)
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/api/nuser/account/get`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/api/nuser/account/get`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
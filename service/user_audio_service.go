@@ -1,14 +1,23 @@
 package service
 
 import (
+	"context"
+
 	"github.com/go-musicfox/netease-music/util"
 )
 
 type UserAudioService struct {
 	UID string `json:"uid" form:"uid"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *UserAudioService) UserAudio() (float64, []byte) {
+	code, reBody, _ := service.UserAudioContext(context.Background())
+	return code, reBody
+}
+
+func (service *UserAudioService) UserAudioContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto: "weapi",
@@ -16,7 +25,7 @@ func (service *UserAudioService) UserAudio() (float64, []byte) {
 	data := make(map[string]string)
 	data["userId"] = service.UID
 
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/weapi/djradio/get/byuser`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/weapi/djradio/get/byuser`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
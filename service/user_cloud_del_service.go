@@ -1,21 +1,30 @@
 package service
 
 import (
+	"context"
+
 	"github.com/go-musicfox/netease-music/util"
 )
 
 type UserCloudDelService struct {
 	ID string `json:"id" form:"id"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *UserCloudDelService) UserCloudDel() (float64, []byte) {
+	code, reBody, _ := service.UserCloudDelContext(context.Background())
+	return code, reBody
+}
+
+func (service *UserCloudDelService) UserCloudDelContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto: "weapi",
 	}
 	data := make(map[string]string)
 	data["songIds"] = "[" + service.ID + "]"
-	code, reBody, _ := util.CreateRequest("POST", `http://music.163.com/weapi/cloud/del`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `http://music.163.com/weapi/cloud/del`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
@@ -1,21 +1,30 @@
 package service
 
 import (
+	"context"
+
 	"github.com/go-musicfox/netease-music/util"
 )
 
 type UserCloudDetailService struct {
 	ID string `json:"id" form:"id"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *UserCloudDetailService) UserCloudDetail() (float64, []byte) {
+	code, reBody, _ := service.UserCloudDetailContext(context.Background())
+	return code, reBody
+}
+
+func (service *UserCloudDetailService) UserCloudDetailContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto: "weapi",
 	}
 	data := make(map[string]string)
 	data["songIds"] = "[" + service.ID + "]"
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/weapi/v1/cloud/get/byids`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/weapi/v1/cloud/get/byids`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
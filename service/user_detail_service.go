@@ -1,20 +1,29 @@
 package service
 
 import (
+	"context"
+
 	"github.com/go-musicfox/netease-music/util"
 )
 
 type UserDetailService struct {
 	Uid string `json:"uid" form:"uid"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *UserDetailService) UserDetail() (float64, []byte) {
+	code, reBody, _ := service.UserDetailContext(context.Background())
+	return code, reBody
+}
+
+func (service *UserDetailService) UserDetailContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto: "weapi",
 	}
 	data := make(map[string]string)
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/weapi/v1/user/detail/`+service.Uid, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/weapi/v1/user/detail/`+service.Uid, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
@@ -1,6 +1,8 @@
 package service
 
 import (
+	"context"
+
 	"github.com/go-musicfox/netease-music/util"
 )
 
@@ -8,9 +10,16 @@ type UserFollowedsService struct {
 	Uid   string `json:"uid" form:"uid"`
 	Limit string `json:"limit" form:"limit"`
 	Time  string `json:"lasttime " form:"lasttime "`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *UserFollowedsService) UserFolloweds() (float64, []byte) {
+	code, reBody, _ := service.UserFollowedsContext(context.Background())
+	return code, reBody
+}
+
+func (service *UserFollowedsService) UserFollowedsContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto: "eapi",
@@ -28,7 +37,7 @@ func (service *UserFollowedsService) UserFolloweds() (float64, []byte) {
 	} else {
 		data["time"] = service.Time
 	}
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/eapi/user/getfolloweds/`+service.Uid, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/eapi/user/getfolloweds/`+service.Uid, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
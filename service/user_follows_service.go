@@ -1,6 +1,8 @@
 package service
 
 import (
+	"context"
+
 	"github.com/go-musicfox/netease-music/util"
 )
 
@@ -8,9 +10,16 @@ type UserFollowsService struct {
 	Uid    string `json:"uid" form:"uid"`
 	Limit  string `json:"limit" form:"limit"`
 	Offset string `json:"offset" form:"offset"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *UserFollowsService) UserFollows() (float64, []byte) {
+	code, reBody, _ := service.UserFollowsContext(context.Background())
+	return code, reBody
+}
+
+func (service *UserFollowsService) UserFollowsContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto: "weapi",
@@ -28,7 +37,7 @@ func (service *UserFollowsService) UserFollows() (float64, []byte) {
 		data["offset"] = service.Offset
 	}
 	data["order"] = "true"
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/weapi/user/getfollows/`+service.Uid, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/weapi/user/getfollows/`+service.Uid, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
@@ -1,15 +1,24 @@
 package service
 
 import (
+	"context"
+
 	"github.com/go-musicfox/netease-music/util"
 )
 
 type UserRecordService struct {
 	UId  string `json:"uid" form:"uid"`
 	Type string `json:"type" form:"type"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *UserRecordService) UserRecord() (float64, []byte) {
+	code, reBody, _ := service.UserRecordContext(context.Background())
+	return code, reBody
+}
+
+func (service *UserRecordService) UserRecordContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto: "weapi",
@@ -22,7 +31,7 @@ func (service *UserRecordService) UserRecord() (float64, []byte) {
 	} else {
 		data["type"] = "0"
 	}
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/weapi/v1/play/record`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/weapi/v1/play/record`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
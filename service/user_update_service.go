@@ -1,6 +1,8 @@
 package service
 
 import (
+	"context"
+
 	"github.com/go-musicfox/netease-music/util"
 )
 
@@ -12,9 +14,16 @@ type UserUpdateService struct {
 	Nickname    string `json:"nickname" form:"nickname"`
 	Province    string `json:"province" form:"province"`
 	Signature   string `json:"signature" form:"signature"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *UserUpdateService) UserUpdate() (float64, []byte) {
+	code, reBody, _ := service.UserUpdateContext(context.Background())
+	return code, reBody
+}
+
+func (service *UserUpdateService) UserUpdateContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto: "weapi",
@@ -28,7 +37,7 @@ func (service *UserUpdateService) UserUpdate() (float64, []byte) {
 	data["province"] = service.Province
 	data["signature"] = service.Signature
 
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/weapi/user/profile/update`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/weapi/user/profile/update`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
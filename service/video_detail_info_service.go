@@ -1,14 +1,23 @@
 package service
 
 import (
+	"context"
+
 	"github.com/go-musicfox/netease-music/util"
 )
 
 type VideoDetailInfoService struct {
 	ID string `json:"vid" form:"vid"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *VideoDetailInfoService) VideoDetailInfo() (float64, []byte) {
+	code, reBody, _ := service.VideoDetailInfoContext(context.Background())
+	return code, reBody
+}
+
+func (service *VideoDetailInfoService) VideoDetailInfoContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto: "weapi",
@@ -16,7 +25,7 @@ func (service *VideoDetailInfoService) VideoDetailInfo() (float64, []byte) {
 	data := make(map[string]string)
 	data["threadid"] = "R_VI_62_" + service.ID
 	data["composeliked"] = "true"
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/api/comment/commentthread/info`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/api/comment/commentthread/info`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
@@ -1,19 +1,27 @@
 package service
 
 import (
+	"context"
+
 	"github.com/go-musicfox/netease-music/util"
 )
 
 type VideoGroupListService struct {
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *VideoGroupListService) VideoGroupList() (float64, []byte) {
+	code, reBody, _ := service.VideoGroupListContext(context.Background())
+	return code, reBody
+}
+
+func (service *VideoGroupListService) VideoGroupListContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto: "weapi",
 	}
 	data := make(map[string]string)
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/api/cloudvideo/group/list`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/api/cloudvideo/group/list`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
@@ -1,15 +1,24 @@
 package service
 
 import (
+	"context"
+
 	"github.com/go-musicfox/netease-music/util"
 )
 
 type VideoGroupService struct {
 	GroupID string `json:"id" form:"id"`
 	Offset  string `json:"offset" form:"offset"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *VideoGroupService) VideoGroup() (float64, []byte) {
+	code, reBody, _ := service.VideoGroupContext(context.Background())
+	return code, reBody
+}
+
+func (service *VideoGroupService) VideoGroupContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto: "weapi",
@@ -23,7 +32,7 @@ func (service *VideoGroupService) VideoGroup() (float64, []byte) {
 	}
 	data["order"] = "true"
 	data["need_preview_url"] = "true"
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/api/videotimeline/videogroup/otherclient/get`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/api/videotimeline/videogroup/otherclient/get`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
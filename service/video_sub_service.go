@@ -1,15 +1,24 @@
 package service
 
 import (
+	"context"
+
 	"github.com/go-musicfox/netease-music/util"
 )
 
 type VideoSubService struct {
 	T  string `json:"t" form:"t"`
 	Id string `json:"id" form:"id"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *VideoSubService) VideoSub() (float64, []byte) {
+	code, reBody, _ := service.VideoSubContext(context.Background())
+	return code, reBody
+}
+
+func (service *VideoSubService) VideoSubContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto: "weapi",
@@ -24,7 +33,7 @@ func (service *VideoSubService) VideoSub() (float64, []byte) {
 
 	data["id"] = service.Id
 
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/weapi/cloudvideo/video/`+service.T, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/weapi/cloudvideo/video/`+service.T, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
@@ -1,14 +1,23 @@
 package service
 
 import (
+	"context"
+
 	"github.com/go-musicfox/netease-music/util"
 )
 
 type VideoTimelineAllService struct {
 	Offset string `json:"offset" form:"offset"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *VideoTimelineAllService) VideoTimelineAll() (float64, []byte) {
+	code, reBody, _ := service.VideoTimelineAllContext(context.Background())
+	return code, reBody
+}
+
+func (service *VideoTimelineAllService) VideoTimelineAllContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto: "weapi",
@@ -22,7 +31,7 @@ func (service *VideoTimelineAllService) VideoTimelineAll() (float64, []byte) {
 	}
 	data["order"] = "true"
 	data["need_preview_url"] = "true"
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/api/videotimeline/otherclient/get`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/api/videotimeline/otherclient/get`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
@@ -1,22 +1,27 @@
 package service
 
 import (
-	"net/http"
+	"context"
 
 	"github.com/go-musicfox/netease-music/util"
 )
 
 type VideoTimelineRecommendService struct {
 	Offset string `json:"offset" form:"offset"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *VideoTimelineRecommendService) VideoTimelineRecommend() (float64, []byte) {
+	code, reBody, _ := service.VideoTimelineRecommendContext(context.Background())
+	return code, reBody
+}
 
-	cookiesOS := &http.Cookie{Name: "os", Value: "pc"}
+func (service *VideoTimelineRecommendService) VideoTimelineRecommendContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto:  "weapi",
-		Cookies: []*http.Cookie{cookiesOS},
+		Profile: util.WebProfile(),
 	}
 	data := make(map[string]string)
 	if service.Offset == "" {
@@ -28,7 +33,7 @@ func (service *VideoTimelineRecommendService) VideoTimelineRecommend() (float64,
 	data["withProgramInfo"] = "true"
 	data["needUrl"] = "1"
 	data["resolution"] = "480"
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/api/videotimeline/get`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/api/videotimeline/get`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
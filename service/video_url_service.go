@@ -1,15 +1,24 @@
 package service
 
 import (
+	"context"
+
 	"github.com/go-musicfox/netease-music/util"
 )
 
 type VideoUrlService struct {
 	ID  string `json:"id" form:"id"`
 	Res string `json:"resolution" form:"resolution"`
+
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *VideoUrlService) VideoUrl() (float64, []byte) {
+	code, reBody, _ := service.VideoUrlContext(context.Background())
+	return code, reBody
+}
+
+func (service *VideoUrlService) VideoUrlContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto: "weapi",
@@ -21,7 +30,7 @@ func (service *VideoUrlService) VideoUrl() (float64, []byte) {
 		data["resolution"] = service.Res
 	}
 	data["ids"] = `["` + service.ID + `"]`
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/weapi/cloudvideo/playurl`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/weapi/cloudvideo/playurl`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
@@ -0,0 +1,267 @@
+package service
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/go-musicfox/netease-music/util"
+)
+
+// serviceURLs service 请求的所有接口地址及其是否会修改账号数据，按操作名拼接的地址列出每种操作
+var serviceURLs = map[string]bool{
+	"http://interface.music.163.com/api/playlist/manipulate/tracks":               true,
+	"http://interface3.music.163.com/eapi/lbs/countries/v1":                       false,
+	"http://interface3.music.163.com/eapi/playlist/desc/update":                   true,
+	"http://interface3.music.163.com/eapi/playlist/tags/update":                   true,
+	"http://interface3.music.163.com/eapi/playlist/update/name":                   true,
+	"http://interface3.music.163.com/eapi/search/defaultkeyword/get":              false,
+	"http://music.163.com/eapi/activate/initProfile":                              true,
+	"http://music.163.com/eapi/cellphone/existence/check":                         false,
+	"http://music.163.com/weapi/act/hot":                                          false,
+	"http://music.163.com/weapi/cloud/del":                                        true,
+	"http://music.163.com/weapi/djradio/banner/get":                               false,
+	"http://music.163.com/weapi/djradio/category/excludehot":                      false,
+	"http://music.163.com/weapi/djradio/home/category/recommend":                  false,
+	"http://music.163.com/weapi/djradio/home/today/perfered":                      false,
+	"http://music.163.com/weapi/playmode/intelligence/list":                       false,
+	"http://music.163.com/weapi/share/friends/resource":                           true,
+	"https://clientlogusf.music.163.com/weapi/feedback/weblog":                    true,
+	"https://interface.music.163.com/api/mv/all":                                  false,
+	"https://interface.music.163.com/api/mv/exclusive/rcmd":                       false,
+	"https://interface.music.163.com/weapi/mv/first":                              false,
+	"https://music.163.com/api/album/sub":                                         true,
+	"https://music.163.com/api/album/unsub":                                       true,
+	"https://music.163.com/api/album/detail/dynamic":                              false,
+	"https://music.163.com/api/artist/top/song":                                   false,
+	"https://music.163.com/api/cloudsearch/pc":                                    false,
+	"https://music.163.com/api/cloudvideo/category/list":                          false,
+	"https://music.163.com/api/cloudvideo/group/list":                             false,
+	"https://music.163.com/api/comment/commentthread/info":                        false,
+	"https://music.163.com/api/comment/hotwall/list/get":                          false,
+	"https://music.163.com/api/digitalAlbum/purchased":                            false,
+	"https://music.163.com/api/discovery/new/albums/area":                         false,
+	"https://music.163.com/api/discovery/newAlbum":                                false,
+	"https://music.163.com/api/discovery/recommend/songs/history/detail":          false,
+	"https://music.163.com/api/discovery/recommend/songs/history/recent":          false,
+	"https://music.163.com/api/dj/toplist/hours":                                  false,
+	"https://music.163.com/api/dj/toplist/newcomer":                               false,
+	"https://music.163.com/api/dj/toplist/popular":                                false,
+	"https://music.163.com/api/djprogram/toplist/hours":                           false,
+	"https://music.163.com/api/djradio/hot":                                       false,
+	"https://music.163.com/api/djradio/toplist":                                   false,
+	"https://music.163.com/api/djradio/toplist/pay":                               false,
+	"https://music.163.com/api/feealbum/songsaleboard/daily/type":                 false,
+	"https://music.163.com/api/forwards/get":                                      false,
+	"https://music.163.com/api/homepage/block/page":                               false,
+	"https://music.163.com/api/login":                                             false,
+	"https://music.163.com/api/msg/notices":                                       false,
+	"https://music.163.com/api/msg/private/history":                               false,
+	"https://music.163.com/api/msg/private/users":                                 false,
+	"https://music.163.com/api/nuser/account/get":                                 false,
+	"https://music.163.com/api/ordering/web/digital":                              true,
+	"https://music.163.com/api/play-record/song/list":                             false,
+	"https://music.163.com/api/playlist/manipulate/tracks":                        true,
+	"https://music.163.com/api/playlist/order/update":                             true,
+	"https://music.163.com/api/playlist/track/add":                                true,
+	"https://music.163.com/api/playlist/track/delete":                             true,
+	"https://music.163.com/api/point/dailyTask":                                   true,
+	"https://music.163.com/api/program/toplist/v1":                                false,
+	"https://music.163.com/api/radio/like":                                        true,
+	"https://music.163.com/api/resource/comment/floor/get":                        false,
+	"https://music.163.com/api/search/voice/get":                                  false,
+	"https://music.163.com/api/song/enhance/player/url":                           false,
+	"https://music.163.com/api/song/lyric":                                        false,
+	"https://music.163.com/api/toplist":                                           false,
+	"https://music.163.com/api/user/login/secure":                                 false,
+	"https://music.163.com/api/user/replaceCellphone":                             true,
+	"https://music.163.com/api/user/setting":                                      false,
+	"https://music.163.com/api/v1/artist/list":                                    false,
+	"https://music.163.com/api/v1/artist/songs":                                   false,
+	"https://music.163.com/api/v1/mv/detail":                                      false,
+	"https://music.163.com/api/v1/resource/comments/R_SO_4_":                      false,
+	"https://music.163.com/api/v1/user/comments/32953014":                         false,
+	"https://music.163.com/api/v2/banner/get":                                     false,
+	"https://music.163.com/api/v2/privatecontent/list":                            false,
+	"https://music.163.com/api/v3/discovery/recommend/songs":                      false,
+	"https://music.163.com/api/videotimeline/get":                                 false,
+	"https://music.163.com/api/videotimeline/otherclient/get":                     false,
+	"https://music.163.com/api/videotimeline/videogroup/otherclient/get":          false,
+	"https://music.163.com/eapi/event/delete":                                     true,
+	"https://music.163.com/eapi/homepage/dragon/ball/static":                      false,
+	"https://music.163.com/eapi/user/getfolloweds/32953014":                       false,
+	"https://music.163.com/weapi/album/new":                                       false,
+	"https://music.163.com/weapi/album/sublist":                                   false,
+	"https://music.163.com/weapi/artist/sub":                                      true,
+	"https://music.163.com/weapi/artist/unsub":                                    true,
+	"https://music.163.com/weapi/artist/albums/6452":                              false,
+	"https://music.163.com/weapi/artist/introduction":                             false,
+	"https://music.163.com/weapi/artist/mvs":                                      false,
+	"https://music.163.com/weapi/artist/sublist":                                  false,
+	"https://music.163.com/weapi/artist/top":                                      false,
+	"https://music.163.com/weapi/batch":                                           true,
+	"https://music.163.com/weapi/cloudvideo/allvideo/sublist":                     false,
+	"https://music.163.com/weapi/cloudvideo/playurl":                              false,
+	"https://music.163.com/weapi/cloudvideo/v1/allvideo/rcmd":                     false,
+	"https://music.163.com/weapi/cloudvideo/v1/video/detail":                      false,
+	"https://music.163.com/weapi/cloudvideo/video/sub":                            true,
+	"https://music.163.com/weapi/cloudvideo/video/unsub":                          true,
+	"https://music.163.com/weapi/discovery/simiArtist":                            false,
+	"https://music.163.com/weapi/discovery/simiMV":                                false,
+	"https://music.163.com/weapi/discovery/simiPlaylist":                          false,
+	"https://music.163.com/weapi/discovery/simiUser":                              false,
+	"https://music.163.com/weapi/dj/program/32953014":                             false,
+	"https://music.163.com/weapi/dj/program/byradio":                              false,
+	"https://music.163.com/weapi/dj/program/detail":                               false,
+	"https://music.163.com/weapi/djradio/sub":                                     true,
+	"https://music.163.com/weapi/djradio/unsub":                                   true,
+	"https://music.163.com/weapi/djradio/category/get":                            false,
+	"https://music.163.com/weapi/djradio/get":                                     false,
+	"https://music.163.com/weapi/djradio/get/byuser":                              false,
+	"https://music.163.com/weapi/djradio/get/subed":                               false,
+	"https://music.163.com/weapi/djradio/home/paygift/list?_nmclfl=1":             false,
+	"https://music.163.com/weapi/djradio/hot/v1":                                  false,
+	"https://music.163.com/weapi/djradio/recommend":                               false,
+	"https://music.163.com/weapi/djradio/recommend/v1":                            false,
+	"https://music.163.com/weapi/event/forward":                                   true,
+	"https://music.163.com/weapi/event/get/32953014":                              false,
+	"https://music.163.com/weapi/hotsearchlist/get":                               false,
+	"https://music.163.com/weapi/login/cellphone":                                 false,
+	"https://music.163.com/weapi/login/qrcode/client/login":                       false,
+	"https://music.163.com/weapi/login/qrcode/unikey":                             false,
+	"https://music.163.com/weapi/login/token/refresh":                             false,
+	"https://music.163.com/weapi/logout":                                          true,
+	"https://music.163.com/weapi/msg/private/send":                                true,
+	"https://music.163.com/weapi/mv/sub":                                          true,
+	"https://music.163.com/weapi/mv/unsub":                                        true,
+	"https://music.163.com/weapi/mv/toplist":                                      false,
+	"https://music.163.com/weapi/personalized/djprogram":                          false,
+	"https://music.163.com/weapi/personalized/mv":                                 false,
+	"https://music.163.com/weapi/personalized/newsong":                            false,
+	"https://music.163.com/weapi/personalized/playlist":                           false,
+	"https://music.163.com/weapi/personalized/privatecontent":                     false,
+	"https://music.163.com/weapi/playlist/subscribe":                              true,
+	"https://music.163.com/weapi/playlist/unsubscribe":                            true,
+	"https://music.163.com/weapi/playlist/catalogue":                              false,
+	"https://music.163.com/weapi/playlist/create":                                 true,
+	"https://music.163.com/weapi/playlist/highquality/list":                       false,
+	"https://music.163.com/weapi/playlist/hottags":                                false,
+	"https://music.163.com/weapi/playlist/list":                                   false,
+	"https://music.163.com/weapi/playlist/remove":                                 true,
+	"https://music.163.com/weapi/playlist/subscribers":                            false,
+	"https://music.163.com/weapi/point/dailyTask":                                 true,
+	"https://music.163.com/weapi/program/recommend/v1":                            false,
+	"https://music.163.com/weapi/radio/trash/add?alg=RT&songId=":                  true,
+	"https://music.163.com/weapi/register/anonimous":                              true,
+	"https://music.163.com/weapi/register/cellphone":                              true,
+	"https://music.163.com/weapi/resource/like":                                   true,
+	"https://music.163.com/weapi/resource/unlike":                                 true,
+	"https://music.163.com/weapi/resource/comments/add":                           true,
+	"https://music.163.com/weapi/resource/comments/delete":                        true,
+	"https://music.163.com/weapi/resource/comments/reply":                         true,
+	"https://music.163.com/weapi/search/hot":                                      false,
+	"https://music.163.com/weapi/search/suggest/web":                              false,
+	"https://music.163.com/weapi/search/suggest/multimatch":                       false,
+	"https://music.163.com/weapi/sms/captcha/sent":                                true,
+	"https://music.163.com/weapi/sms/captcha/verify":                              false,
+	"https://music.163.com/weapi/song/enhance/play/mv/url":                        false,
+	"https://music.163.com/weapi/song/enhance/player/url/v1":                      false,
+	"https://music.163.com/weapi/song/like/get":                                   false,
+	"https://music.163.com/weapi/subcount":                                        false,
+	"https://music.163.com/weapi/toplist/artist":                                  false,
+	"https://music.163.com/weapi/toplist/detail":                                  false,
+	"https://music.163.com/weapi/user/follow/32953014":                            true,
+	"https://music.163.com/weapi/user/delfollow/32953014":                         true,
+	"https://music.163.com/weapi/user/getfollows/32953014":                        false,
+	"https://music.163.com/weapi/user/playlist":                                   false,
+	"https://music.163.com/weapi/user/profile/update":                             true,
+	"https://music.163.com/weapi/v1/album/32311":                                  false,
+	"https://music.163.com/weapi/v1/artist/6452":                                  false,
+	"https://music.163.com/weapi/v1/cloud/get":                                    false,
+	"https://music.163.com/weapi/v1/cloud/get/byids":                              false,
+	"https://music.163.com/weapi/v1/comment/like":                                 true,
+	"https://music.163.com/weapi/v1/comment/unlike":                               true,
+	"https://music.163.com/weapi/v1/discovery/new/songs":                          false,
+	"https://music.163.com/weapi/v1/discovery/recommend/resource":                 false,
+	"https://music.163.com/weapi/v1/discovery/simiSong":                           false,
+	"https://music.163.com/weapi/v1/event/get":                                    false,
+	"https://music.163.com/weapi/v1/play/record":                                  false,
+	"https://music.163.com/weapi/v1/radio/get":                                    false,
+	"https://music.163.com/weapi/v1/resource/comments/A_EV_2_6559519868_32953014": false,
+	"https://music.163.com/weapi/v1/resource/comments/A_DJ_1_":                    false,
+	"https://music.163.com/weapi/v1/resource/comments/A_PL_0_":                    false,
+	"https://music.163.com/weapi/v1/resource/comments/R_AL_3_":                    false,
+	"https://music.163.com/weapi/v1/resource/comments/R_MV_5_":                    false,
+	"https://music.163.com/weapi/v1/resource/comments/R_VI_62_":                   false,
+	"https://music.163.com/weapi/v1/resource/hotcomments/R_SO_4_186016":           false,
+	"https://music.163.com/weapi/v1/user/detail/32953014":                         false,
+	"https://music.163.com/weapi/v3/playlist/detail":                              false,
+	"https://music.163.com/weapi/v3/song/detail":                                  false,
+	"https://music.163.com/weapi/vipmall/albumproduct/detail":                     false,
+	"https://music.163.com/weapi/vipmall/albumproduct/list":                       false,
+	"https://music.163.com/weapi/vipmall/appalbum/album/style":                    false,
+	"/api/activate/initProfile":                                                   true,
+	"/api/cellphone/existence/check":                                              false,
+	"/api/homepage/dragon/ball/static":                                            false,
+	"/api/lbs/countries/v1":                                                       false,
+	"/api/playlist/desc/update":                                                   true,
+	"/api/playlist/tags/update":                                                   true,
+	"/api/playlist/update/name":                                                   true,
+	"/api/search/defaultkeyword/get":                                              false,
+	"/api/user/getfolloweds":                                                      false,
+	"/eapi/lbs/countries/v1":                                                      false,
+	"/eapi/playlist/desc/update":                                                  true,
+	"/eapi/playlist/tags/update":                                                  true,
+	"/eapi/playlist/update/name":                                                  true,
+	"/eapi/search/defaultkeyword/get":                                             false,
+}
+
+func TestIsWriteAPI_ServiceURLs(t *testing.T) {
+	for url, want := range serviceURLs {
+		if got := util.IsWriteAPI(url); got != want {
+			t.Errorf("IsWriteAPI(%q) = %v, want %v", url, got, want)
+		}
+	}
+}
+
+// TestServiceURLs_Complete 新增的 service 地址需要加入 serviceURLs
+func TestServiceURLs_Complete(t *testing.T) {
+	files, err := filepath.Glob("*.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fset := token.NewFileSet()
+	for _, name := range files {
+		if strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		f, err := parser.ParseFile(fset, name, nil, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ast.Inspect(f, func(n ast.Node) bool {
+			lit, ok := n.(*ast.BasicLit)
+			if !ok || lit.Kind != token.STRING {
+				return true
+			}
+			s, _ := strconv.Unquote(lit.Value)
+			if strings.Contains(s, "api/") && !coveredURL(s) {
+				t.Errorf("%s: %s not in serviceURLs", fset.Position(lit.Pos()), s)
+			}
+			return true
+		})
+	}
+}
+
+func coveredURL(s string) bool {
+	for url := range serviceURLs {
+		if strings.HasPrefix(url, s) {
+			return true
+		}
+	}
+	return false
+}
@@ -1,13 +1,21 @@
 package service
 
 import (
+	"context"
+
 	"github.com/go-musicfox/netease-music/util"
 )
 
 type YunbeiSigninService struct {
+	Client *util.Client `json:"-" form:"-"`
 }
 
 func (service *YunbeiSigninService) Signin() (float64, []byte) {
+	code, reBody, _ := service.SigninContext(context.Background())
+	return code, reBody
+}
+
+func (service *YunbeiSigninService) SigninContext(ctx context.Context) (float64, []byte, error) {
 
 	options := &util.Options{
 		Crypto: "weapi",
@@ -16,7 +24,7 @@ func (service *YunbeiSigninService) Signin() (float64, []byte) {
 		"type": "0",
 	}
 
-	code, reBody, _ := util.CreateRequest("POST", `https://music.163.com/api/point/dailyTask`, data, options)
+	code, reBody, _, err := service.Client.CreateRequestContext(ctx, "POST", `https://music.163.com/api/point/dailyTask`, data, options)
 
-	return code, reBody
+	return code, reBody, err
 }
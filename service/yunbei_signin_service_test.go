@@ -3,10 +3,15 @@ package service
 import (
 	"fmt"
 	"testing"
+
+	"github.com/go-musicfox/netease-music/neteasetest"
 )
 
 func TestYunbeiSigninService_Signin(t *testing.T) {
-	service := &YunbeiSigninService{}
+	server := neteasetest.NewServer()
+	defer server.Close()
+
+	service := &YunbeiSigninService{Client: server.NewClient()}
 	code, resp := service.Signin()
 	fmt.Println(code, string(resp))
 	if code != 301 {
@@ -3,11 +3,16 @@ package service_test
 import (
 	"testing"
 
+	"github.com/go-musicfox/netease-music/neteasetest"
 	"github.com/go-musicfox/netease-music/service"
 )
 
 func TestCaptchaSentService(t *testing.T) {
+	server := neteasetest.NewServer()
+	defer server.Close()
+
 	service := &service.CaptchaSentService{
+		Client:    server.NewClient(),
 		Cellphone: "",
 	}
 	code, bodyBytes := service.CaptchaSent()
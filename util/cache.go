@@ -0,0 +1,379 @@
+package util
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	urlpkg "net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CacheEntry 缓存的响应
+type CacheEntry struct {
+	StatusCode int       `json:"status"`
+	Body       []byte    `json:"body"`
+	StoredAt   time.Time `json:"stored_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+}
+
+// CacheStore 缓存的存储后端
+//
+// 过期的条目仍应保留，由 Cache 判断是否过期
+type CacheStore interface {
+	Get(key string) (*CacheEntry, bool)
+	Set(key string, entry *CacheEntry) error
+	Delete(key string)
+}
+
+// Cache 按接口缓存响应
+//
+// 缓存的键由请求方法、接口地址、加密前的参数与当前登录账号组成，只有设置了缓存时间的接口才会缓存，
+// 修改账号数据的接口（见 IsWriteAPI）与登录相关的接口永远不会缓存，业务状态码不为200的响应也不会缓存。
+// 单次请求可以通过 WithoutCache、WithCacheRefresh 跳过缓存。
+//
+// 开启 Offline 后，设置了离线有效期的接口在网络请求失败时会返回最近一次成功的响应，
+// 即使它已经过期，调用方可以通过 WithResponseInfo 判断响应是否过期。
+type Cache struct {
+	Store CacheStore
+	// Offline 网络请求失败时是否返回过期的缓存
+	Offline bool
+
+	mu       sync.RWMutex
+	ttls     map[string]time.Duration
+	maxStale map[string]time.Duration
+}
+
+// NewCache 创建使用 store 存储、按 DefaultCacheTTLs、DefaultMaxStale 设置缓存时间与离线有效期的 Cache，store 为 nil 时使用 NewMemoryCache
+func NewCache(store CacheStore) *Cache {
+	if store == nil {
+		store = NewMemoryCache(0)
+	}
+	c := &Cache{Store: store}
+	for prefix, ttl := range DefaultCacheTTLs() {
+		c.SetTTL(prefix, ttl)
+	}
+	for prefix, maxStale := range DefaultMaxStale() {
+		c.SetMaxStale(prefix, maxStale)
+	}
+	return c
+}
+
+// DefaultCacheTTLs 返回很少变化的数据的默认缓存时间
+func DefaultCacheTTLs() map[string]time.Duration {
+	return map[string]time.Duration{
+		"/api/toplist":                  time.Hour,
+		"/api/toplist/detail":           time.Hour,
+		"/api/playlist/catalogue":       24 * time.Hour,
+		"/api/playlist/hottags":         24 * time.Hour,
+		"/api/djradio/category/get":     24 * time.Hour,
+		"/api/lbs/countries/v1":         7 * 24 * time.Hour,
+		"/api/artist/introduction":      24 * time.Hour,
+		"/api/song/lyric":               24 * time.Hour,
+		"/api/v1/discovery/new/songs":   10 * time.Minute,
+		"/api/personalized/playlist":    10 * time.Minute,
+		"/api/discovery/newAlbum":       time.Hour,
+		"/api/cloudvideo/category/list": 24 * time.Hour,
+	}
+}
+
+// DefaultMaxStale 返回离线时可以返回过期响应的接口及其离线有效期，包括歌单详情、歌曲详情、歌词与用户歌单
+func DefaultMaxStale() map[string]time.Duration {
+	return map[string]time.Duration{
+		"/api/v3/playlist/detail": 7 * 24 * time.Hour,
+		"/api/v6/playlist/detail": 7 * 24 * time.Hour,
+		"/api/v3/song/detail":     30 * 24 * time.Hour,
+		"/api/song/lyric":         30 * 24 * time.Hour,
+		"/api/user/playlist":      7 * 24 * time.Hour,
+	}
+}
+
+// SetTTL 设置路径以 prefix 开头的接口的缓存时间，ttl 小于等于0时不缓存
+//
+// 前缀中的 /weapi/、/eapi/ 等会被统一为 /api/，同一请求匹配多个前缀时以最长的为准
+func (c *Cache) SetTTL(prefix string, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.ttls = setPrefixDuration(c.ttls, prefix, ttl)
+}
+
+// SetMaxStale 设置路径以 prefix 开头的接口的离线有效期，即 Offline 时最多返回多久以前缓存的响应，小于等于0时不返回过期响应
+//
+// 前缀的匹配方式与 SetTTL 相同。只设置了离线有效期的接口每次仍会请求网络，成功的响应只用于离线时返回
+func (c *Cache) SetMaxStale(prefix string, maxStale time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.maxStale = setPrefixDuration(c.maxStale, prefix, maxStale)
+}
+
+// TTL 返回 url 对应接口的缓存时间，不缓存时返回0
+func (c *Cache) TTL(url string) time.Duration {
+	return c.lookup(c.ttls, url)
+}
+
+// MaxStale 返回 url 对应接口的离线有效期，未开启 Offline 或不返回过期响应时返回0
+func (c *Cache) MaxStale(url string) time.Duration {
+	if !c.Offline {
+		return 0
+	}
+	return c.lookup(c.maxStale, url)
+}
+
+func (c *Cache) lookup(durations map[string]time.Duration, url string) time.Duration {
+	path := apiPath(url)
+	if IsWriteAPI(path) || isAuthAPI(path) {
+		return 0
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	var (
+		d       time.Duration
+		matched int
+	)
+	for prefix, v := range durations {
+		if strings.HasPrefix(path, prefix) && len(prefix) > matched {
+			d, matched = v, len(prefix)
+		}
+	}
+	return d
+}
+
+func setPrefixDuration(durations map[string]time.Duration, prefix string, d time.Duration) map[string]time.Duration {
+	if durations == nil {
+		durations = make(map[string]time.Duration)
+	}
+	prefix = apiPath(prefix)
+	if d <= 0 {
+		delete(durations, prefix)
+	} else {
+		durations[prefix] = d
+	}
+	return durations
+}
+
+type cacheModeKey struct{}
+
+type cacheMode int
+
+const (
+	cacheSkip cacheMode = iota + 1
+	cacheRefresh
+)
+
+// WithoutCache 使 ctx 发出的请求既不读取也不写入缓存
+func WithoutCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, cacheModeKey{}, cacheSkip)
+}
+
+// WithCacheRefresh 使 ctx 发出的请求跳过缓存直接请求，并用新的响应更新缓存
+func WithCacheRefresh(ctx context.Context) context.Context {
+	return context.WithValue(ctx, cacheModeKey{}, cacheRefresh)
+}
+
+func cacheModeFrom(ctx context.Context) cacheMode {
+	mode, _ := ctx.Value(cacheModeKey{}).(cacheMode)
+	return mode
+}
+
+// ResponseInfo 记录响应的来源，通过 WithResponseInfo 获取
+type ResponseInfo struct {
+	// FromCache 响应是否来自缓存
+	FromCache bool
+	// Stale 是否因网络请求失败返回了过期的缓存
+	Stale bool
+	// StoredAt 缓存响应的时间，响应不是来自缓存时为零值
+	StoredAt time.Time
+
+	mu sync.Mutex
+}
+
+func (info *ResponseInfo) record(entry *CacheEntry, stale bool) {
+	info.mu.Lock()
+	defer info.mu.Unlock()
+	info.FromCache = true
+	info.Stale = info.Stale || stale
+	if info.StoredAt.IsZero() || entry.StoredAt.Before(info.StoredAt) {
+		info.StoredAt = entry.StoredAt
+	}
+}
+
+type responseInfoKey struct{}
+
+// WithResponseInfo 使 ctx 发出的请求将响应的来源记录到 info 中
+//
+// 同一个 ctx 发出多个请求时（如 PlaylistTrackAllService），只要有一个响应来自缓存或已过期就会被记录，StoredAt 为其中最早的时间
+func WithResponseInfo(ctx context.Context, info *ResponseInfo) context.Context {
+	return context.WithValue(ctx, responseInfoKey{}, info)
+}
+
+func responseInfoFrom(ctx context.Context) *ResponseInfo {
+	info, _ := ctx.Value(responseInfoKey{}).(*ResponseInfo)
+	return info
+}
+
+// cacheInterceptor 命中未过期的缓存时直接返回，否则请求后缓存成功的响应；
+// 开启 Offline 时网络请求失败则返回离线有效期内的过期响应
+func (c *Client) cacheInterceptor(ctx context.Context, call *Call, next Invoker) (*Reply, error) {
+	cache := c.Cache
+	mode := cacheModeFrom(ctx)
+	ttl, maxStale := cache.TTL(call.URL), cache.MaxStale(call.URL)
+	if (ttl <= 0 && maxStale <= 0) || mode == cacheSkip {
+		return next(ctx, call)
+	}
+
+	key := c.cacheKey(call)
+	if mode != cacheRefresh && ttl > 0 {
+		if entry, ok := cache.Store.Get(key); ok && time.Now().Before(entry.ExpiresAt) {
+			if info := responseInfoFrom(ctx); info != nil {
+				info.record(entry, false)
+			}
+			return &Reply{StatusCode: entry.StatusCode, Body: entry.Body}, nil
+		}
+	}
+
+	reply, err := next(ctx, call)
+	if err != nil {
+		// 调用方主动取消时不返回过期的缓存，超时则视为网络不可用
+		if maxStale > 0 && !errors.Is(ctx.Err(), context.Canceled) && errors.Is(err, ErrTransport) && !errors.Is(err, ErrCassetteMiss) {
+			if entry, ok := cache.Store.Get(key); ok && time.Since(entry.StoredAt) <= maxStale {
+				c.logger().WarnContext(ctx, "netease cache stale", "url", call.URL, "stored_at", entry.StoredAt, "error", err.Error())
+				if info := responseInfoFrom(ctx); info != nil {
+					info.record(entry, true)
+				}
+				return &Reply{StatusCode: entry.StatusCode, Body: entry.Body}, nil
+			}
+		}
+		return reply, err
+	}
+	if code, _ := responseCode(call.URL, reply.StatusCode, reply.Body); code == 200 {
+		now := time.Now()
+		// 只用于离线的响应立即过期
+		entry := &CacheEntry{StatusCode: reply.StatusCode, Body: reply.Body, StoredAt: now, ExpiresAt: now.Add(ttl)}
+		if err := cache.Store.Set(key, entry); err != nil {
+			c.logger().WarnContext(ctx, "netease cache", "url", call.URL, "error", err.Error())
+		}
+	}
+	return reply, nil
+}
+
+// cacheKey 由请求方法、接口地址、参数以及当前账号组成，避免不同账号共享个性化的响应
+func (c *Client) cacheKey(call *Call) string {
+	var account string
+	if u, err := urlpkg.Parse(call.URL); err == nil {
+		if musicU := CookieValueByName(c.CookieJar().Cookies(u), "MUSIC_U", ""); musicU != "" {
+			sum := sha256.Sum256([]byte(musicU))
+			account = hex.EncodeToString(sum[:8])
+		}
+	}
+	return call.Method + " " + cassetteURL(call.URL) + " " + canonicalParams(call.Params, nil) + " " + account
+}
+
+// MemoryCache 内存中的 CacheStore
+type MemoryCache struct {
+	mu         sync.Mutex
+	maxEntries int
+	entries    map[string]*CacheEntry
+}
+
+// NewMemoryCache 创建最多保存 maxEntries 条响应的内存缓存，超出时淘汰最早写入的，maxEntries 小于等于0时不限制
+func NewMemoryCache(maxEntries int) *MemoryCache {
+	return &MemoryCache{maxEntries: maxEntries, entries: make(map[string]*CacheEntry)}
+}
+
+func (m *MemoryCache) Get(key string) (*CacheEntry, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	entry, ok := m.entries[key]
+	return entry, ok
+}
+
+func (m *MemoryCache) Set(key string, entry *CacheEntry) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.entries[key] = entry
+	for m.maxEntries > 0 && len(m.entries) > m.maxEntries {
+		var (
+			oldestKey string
+			oldest    time.Time
+		)
+		for k, e := range m.entries {
+			if oldestKey == "" || e.StoredAt.Before(oldest) {
+				oldestKey, oldest = k, e.StoredAt
+			}
+		}
+		delete(m.entries, oldestKey)
+	}
+	return nil
+}
+
+func (m *MemoryCache) Delete(key string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.entries, key)
+}
+
+// DiskCache 以文件形式保存在目录中的 CacheStore，进程重启后缓存仍然有效
+type DiskCache struct {
+	dir string
+}
+
+// NewDiskCache 创建保存在 dir 目录下的缓存，目录不存在时会被创建
+func NewDiskCache(dir string) (*DiskCache, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("create cache dir: %w", err)
+	}
+	return &DiskCache{dir: dir}, nil
+}
+
+func (d *DiskCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(d.dir, hex.EncodeToString(sum[:])+".json")
+}
+
+func (d *DiskCache) Get(key string) (*CacheEntry, bool) {
+	content, err := os.ReadFile(d.path(key))
+	if err != nil {
+		return nil, false
+	}
+	var entry CacheEntry
+	if err := json.Unmarshal(content, &entry); err != nil {
+		return nil, false
+	}
+	return &entry, true
+}
+
+func (d *DiskCache) Set(key string, entry *CacheEntry) error {
+	content, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("marshal cache entry: %w", err)
+	}
+	// 先写入临时文件再重命名，避免并发读取到不完整的内容
+	f, err := os.CreateTemp(d.dir, "tmp-*")
+	if err != nil {
+		return fmt.Errorf("write cache entry: %w", err)
+	}
+	if _, err := f.Write(content); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return fmt.Errorf("write cache entry: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return fmt.Errorf("write cache entry: %w", err)
+	}
+	if err := os.Rename(f.Name(), d.path(key)); err != nil {
+		os.Remove(f.Name())
+		return fmt.Errorf("write cache entry: %w", err)
+	}
+	return nil
+}
+
+func (d *DiskCache) Delete(key string) {
+	_ = os.Remove(d.path(key))
+}
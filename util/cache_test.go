@@ -0,0 +1,260 @@
+package util
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newCacheClient(calls *int32, body string) *Client {
+	return &Client{
+		Cache: NewCache(nil),
+		Transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			atomic.AddInt32(calls, 1)
+			return &http.Response{StatusCode: 200, Header: http.Header{}, Body: newBody(body), Request: r}, nil
+		}),
+	}
+}
+
+func TestCache_Hit(t *testing.T) {
+	var calls int32
+	client := newCacheClient(&calls, `{"code":200,"list":[]}`)
+	request := func(ctx context.Context, data map[string]string) []byte {
+		code, body, _, err := client.CreateRequestContext(ctx, "POST", "https://music.163.com/weapi/toplist", data, &Options{Crypto: "weapi"})
+		if err != nil || code != 200 {
+			t.Fatalf("code %f, err %v", code, err)
+		}
+		return body
+	}
+
+	for i := 0; i < 3; i++ {
+		if body := request(context.Background(), map[string]string{}); string(body) != `{"code":200,"list":[]}` {
+			t.Fatalf("unexpected body: %s", body)
+		}
+	}
+	if calls != 1 {
+		t.Fatalf("expected 1 request, got %d", calls)
+	}
+
+	// 参数不同时不命中
+	request(context.Background(), map[string]string{"limit": "10"})
+	if calls != 2 {
+		t.Fatalf("expected 2 requests, got %d", calls)
+	}
+
+	// WithoutCache 既不读取也不写入
+	request(WithoutCache(context.Background()), map[string]string{"limit": "20"})
+	request(context.Background(), map[string]string{"limit": "20"})
+	if calls != 4 {
+		t.Fatalf("expected 4 requests, got %d", calls)
+	}
+
+	// WithCacheRefresh 跳过读取但更新缓存
+	request(WithCacheRefresh(context.Background()), map[string]string{})
+	request(context.Background(), map[string]string{})
+	if calls != 5 {
+		t.Fatalf("expected 5 requests, got %d", calls)
+	}
+
+	// CallWeapi 同样经过缓存
+	for i := 0; i < 2; i++ {
+		if code, _, err := client.CallWeapi("https://music.163.com/weapi/song/lyric", map[string]interface{}{"id": "1"}); err != nil || code != 200 {
+			t.Fatalf("code %f, err %v", code, err)
+		}
+	}
+	if calls != 6 {
+		t.Fatalf("expected 6 requests, got %d", calls)
+	}
+}
+
+func TestCache_Expire(t *testing.T) {
+	var calls int32
+	client := newCacheClient(&calls, `{"code":200}`)
+	client.Cache.SetTTL("/api/toplist", 20*time.Millisecond)
+
+	for i := 0; i < 2; i++ {
+		_, _, _, _ = client.CreateRequestContext(context.Background(), "POST", "https://music.163.com/weapi/toplist", map[string]string{}, &Options{Crypto: "weapi"})
+	}
+	time.Sleep(30 * time.Millisecond)
+	_, _, _, _ = client.CreateRequestContext(context.Background(), "POST", "https://music.163.com/weapi/toplist", map[string]string{}, &Options{Crypto: "weapi"})
+	if calls != 2 {
+		t.Fatalf("expected 2 requests, got %d", calls)
+	}
+}
+
+func TestCache_TTL(t *testing.T) {
+	c := NewCache(nil)
+	c.SetTTL("/weapi/playlist/detail", time.Minute)
+	c.SetTTL("/api/playlist/detail/dynamic", time.Second)
+	c.SetTTL("/api/login", time.Minute)
+	c.SetTTL("/api/playlist/manipulate/tracks", time.Minute)
+
+	tests := map[string]time.Duration{
+		"https://music.163.com/eapi/playlist/detail":             time.Minute,
+		"https://music.163.com/api/playlist/detail/dynamic":      time.Second,
+		"https://music.163.com/weapi/toplist/detail":             time.Hour,
+		"https://music.163.com/weapi/cloudsearch/pc":             0,
+		"https://music.163.com/weapi/login/cellphone":            0,
+		"https://music.163.com/weapi/playlist/manipulate/tracks": 0,
+	}
+	for u, want := range tests {
+		if got := c.TTL(u); got != want {
+			t.Errorf("TTL(%s) = %s, want %s", u, got, want)
+		}
+	}
+
+	c.SetTTL("/api/toplist", 0)
+	if got := c.TTL("/api/toplist"); got != 0 {
+		t.Errorf("ttl not removed: %s", got)
+	}
+}
+
+func TestCache_NotCached(t *testing.T) {
+	var calls int32
+	client := newCacheClient(&calls, `{"code":301}`)
+	for i := 0; i < 2; i++ {
+		_, _, _, _ = client.CreateRequestContext(context.Background(), "POST", "https://music.163.com/weapi/toplist", map[string]string{}, &Options{Crypto: "weapi"})
+	}
+	if calls != 2 {
+		t.Fatalf("failed response cached, requests %d", calls)
+	}
+
+	calls = 0
+	client = newCacheClient(&calls, `{"code":200}`)
+	client.Cache.SetTTL("/api/login", time.Hour)
+	for i := 0; i < 2; i++ {
+		_, _, _, _ = client.CreateRequestContext(context.Background(), "POST", "https://music.163.com/weapi/login/cellphone", map[string]string{"phone": "1"}, &Options{Crypto: "weapi"})
+	}
+	if calls != 2 {
+		t.Fatalf("login response cached, requests %d", calls)
+	}
+}
+
+func TestCache_Account(t *testing.T) {
+	var calls int32
+	client := newCacheClient(&calls, `{"code":200}`)
+	u, _ := url.Parse("https://music.163.com")
+	request := func() {
+		if code, _, _, err := client.CreateRequestContext(context.Background(), "POST", "https://music.163.com/weapi/personalized/playlist", map[string]string{}, &Options{Crypto: "weapi"}); err != nil || code != 200 {
+			t.Fatalf("code %f, err %v", code, err)
+		}
+	}
+
+	client.CookieJar().SetCookies(u, []*http.Cookie{{Name: "MUSIC_U", Value: "a", Path: "/"}})
+	request()
+	request()
+	jar, _ := cookiejar.New(nil)
+	jar.SetCookies(u, []*http.Cookie{{Name: "MUSIC_U", Value: "b", Path: "/"}})
+	client.SetCookieJar(jar)
+	request()
+	if calls != 2 {
+		t.Fatalf("accounts share cache, requests %d", calls)
+	}
+}
+
+func TestMemoryCache_Evict(t *testing.T) {
+	m := NewMemoryCache(2)
+	now := time.Now()
+	for i, key := range []string{"a", "b", "c"} {
+		_ = m.Set(key, &CacheEntry{StoredAt: now.Add(time.Duration(i) * time.Second)})
+	}
+	if _, ok := m.Get("a"); ok {
+		t.Error("oldest entry not evicted")
+	}
+	for _, key := range []string{"b", "c"} {
+		if _, ok := m.Get(key); !ok {
+			t.Errorf("entry %s evicted", key)
+		}
+	}
+	m.Delete("b")
+	if _, ok := m.Get("b"); ok {
+		t.Error("entry not deleted")
+	}
+}
+
+func TestDiskCache(t *testing.T) {
+	dir := t.TempDir()
+	d, err := NewDiskCache(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	entry := &CacheEntry{StatusCode: 200, Body: []byte(`{"code":200}`), StoredAt: time.Now(), ExpiresAt: time.Now().Add(time.Hour)}
+	if err := d.Set("POST /api/toplist {}", entry); err != nil {
+		t.Fatal(err)
+	}
+
+	// 重新打开后仍然有效
+	d, _ = NewDiskCache(dir)
+	got, ok := d.Get("POST /api/toplist {}")
+	if !ok || got.StatusCode != 200 || string(got.Body) != `{"code":200}` || !got.ExpiresAt.Equal(entry.ExpiresAt) {
+		t.Fatalf("unexpected entry: %+v", got)
+	}
+	d.Delete("POST /api/toplist {}")
+	if _, ok := d.Get("POST /api/toplist {}"); ok {
+		t.Fatal("entry not deleted")
+	}
+}
+
+func TestCache_Offline(t *testing.T) {
+	var (
+		calls int32
+		down  atomic.Bool
+	)
+	client := &Client{
+		Cache: NewCache(nil),
+		Transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			atomic.AddInt32(&calls, 1)
+			if down.Load() {
+				return nil, errors.New("network is unreachable")
+			}
+			return &http.Response{StatusCode: 200, Header: http.Header{}, Body: newBody(`{"code":200,"playlist":{}}`), Request: r}, nil
+		}),
+	}
+	request := func(ctx context.Context) (float64, []byte, error) {
+		code, body, _, err := client.CreateRequestContext(ctx, "POST", "https://music.163.com/weapi/v3/playlist/detail", map[string]string{"id": "1"}, &Options{Crypto: "weapi"})
+		return code, body, err
+	}
+
+	// 未开启 Offline 时不保存也不返回
+	_, _, _ = request(context.Background())
+	down.Store(true)
+	if code, _, err := request(context.Background()); !errors.Is(err, ErrTransport) || code != 520 {
+		t.Fatalf("code %f, err %v", code, err)
+	}
+
+	client.Cache.Offline = true
+	down.Store(false)
+	var info ResponseInfo
+	if _, _, err := request(WithResponseInfo(context.Background(), &info)); err != nil || info.FromCache {
+		t.Fatalf("err %v, info %+v", err, &info)
+	}
+	// 只用于离线的响应不会在网络正常时返回
+	if _, _, _ = request(context.Background()); calls != 4 {
+		t.Fatalf("expected 4 requests, got %d", calls)
+	}
+
+	down.Store(true)
+	code, body, err := request(WithResponseInfo(context.Background(), &info))
+	if err != nil || code != 200 || string(body) != `{"code":200,"playlist":{}}` {
+		t.Fatalf("code %f, body %s, err %v", code, body, err)
+	}
+	if !info.FromCache || !info.Stale {
+		t.Fatalf("stale response not reported: %+v", &info)
+	}
+
+	// 主动取消或超出离线有效期时不返回
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, _, err := request(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected canceled, got %v", err)
+	}
+	client.Cache.SetMaxStale("/api/v3/playlist/detail", time.Nanosecond)
+	if _, _, err := request(context.Background()); !errors.Is(err, ErrTransport) {
+		t.Fatalf("expected transport error, got %v", err)
+	}
+}
@@ -0,0 +1,204 @@
+package util
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	urlpkg "net/url"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// ErrCassetteMiss 回放模式下没有与请求匹配的录制记录
+var ErrCassetteMiss = errors.New("netease: cassette 中没有匹配的请求")
+
+// CassetteMode Cassette 的工作模式
+type CassetteMode int
+
+const (
+	// CassetteRecord 正常发送请求，并记录请求与响应
+	CassetteRecord CassetteMode = iota + 1
+	// CassetteReplay 不发送请求，直接返回录制的响应
+	CassetteReplay
+)
+
+// Interaction 一次录制的请求与响应
+//
+// Params 是加密前的请求参数，weapi 等加密使用随机密钥，密文无法用于匹配
+type Interaction struct {
+	Method  string                 `json:"method"`
+	URL     string                 `json:"url"`
+	Crypto  string                 `json:"crypto,omitempty"`
+	Params  map[string]interface{} `json:"params"`
+	Status  int                    `json:"status"`
+	Body    string                 `json:"body"`
+	Cookies []string               `json:"cookies,omitempty"` // 响应的 Set-Cookie
+}
+
+// Cassette 录制与回放 Client 发出的请求，用于编写不依赖网络的回归测试或复现用户反馈的问题
+//
+// 录制模式下通过 Save 写入文件；回放模式下按请求方法、接口地址与参数匹配录制记录，
+// 相同的请求依次返回各次录制的响应，用完后一直返回最后一个。
+// 录制文件中包含响应设置的 Cookie（如 MUSIC_U），分享前请注意脱敏。
+type Cassette struct {
+	Mode CassetteMode
+	Path string
+	// IgnoreParams 匹配时忽略的参数，csrf_token 总是被忽略
+	IgnoreParams []string
+
+	mu           sync.Mutex
+	interactions []Interaction
+	used         []bool
+}
+
+// NewCassette 创建一个录制模式的 Cassette，Save 时写入 path
+func NewCassette(path string) *Cassette {
+	return &Cassette{Mode: CassetteRecord, Path: path}
+}
+
+// LoadCassette 读取 path 中录制的请求，返回回放模式的 Cassette
+func LoadCassette(path string) (*Cassette, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read cassette: %w", err)
+	}
+	var file struct {
+		Interactions []Interaction `json:"interactions"`
+	}
+	if err := json.Unmarshal(content, &file); err != nil {
+		return nil, fmt.Errorf("unmarshal cassette %s: %w", path, err)
+	}
+	return &Cassette{
+		Mode:         CassetteReplay,
+		Path:         path,
+		interactions: file.Interactions,
+		used:         make([]bool, len(file.Interactions)),
+	}, nil
+}
+
+// Interactions 返回已录制或已加载的全部记录
+func (c *Cassette) Interactions() []Interaction {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]Interaction(nil), c.interactions...)
+}
+
+// Save 将录制的记录写入 Path
+func (c *Cassette) Save() error {
+	c.mu.Lock()
+	file := struct {
+		Interactions []Interaction `json:"interactions"`
+	}{c.interactions}
+	content, err := json.MarshalIndent(file, "", "  ")
+	c.mu.Unlock()
+	if err != nil {
+		return fmt.Errorf("marshal cassette: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(c.Path), 0o755); err != nil {
+		return fmt.Errorf("save cassette: %w", err)
+	}
+	if err := os.WriteFile(c.Path, content, 0o600); err != nil {
+		return fmt.Errorf("save cassette: %w", err)
+	}
+	return nil
+}
+
+func (c *Cassette) replaying() bool {
+	return c != nil && c.Mode == CassetteReplay
+}
+
+func (c *Cassette) recording() bool {
+	return c != nil && c.Mode == CassetteRecord
+}
+
+func (c *Cassette) record(method, url, crypto string, params map[string]interface{}, status int, body []byte, cookies []*http.Cookie) {
+	i := Interaction{
+		Method: method,
+		URL:    cassetteURL(url),
+		Crypto: crypto,
+		Params: params,
+		Status: status,
+		Body:   string(body),
+	}
+	for _, cookie := range cookies {
+		i.Cookies = append(i.Cookies, cookie.String())
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.interactions = append(c.interactions, i)
+	c.used = append(c.used, false)
+}
+
+// match 返回与请求匹配的记录，优先返回尚未使用过的
+func (c *Cassette) match(method, url string, params map[string]interface{}) (*Interaction, error) {
+	url = cassetteURL(url)
+	want := c.canonicalParams(params)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	last := -1
+	for i := range c.interactions {
+		in := &c.interactions[i]
+		if in.Method != method || in.URL != url || c.canonicalParams(in.Params) != want {
+			continue
+		}
+		if !c.used[i] {
+			c.used[i] = true
+			return in, nil
+		}
+		last = i
+	}
+	if last >= 0 {
+		return &c.interactions[last], nil
+	}
+	return nil, fmt.Errorf("%w: %s %s %s", ErrCassetteMiss, method, url, want)
+}
+
+func (c *Cassette) canonicalParams(params map[string]interface{}) string {
+	return canonicalParams(params, c.IgnoreParams)
+}
+
+// canonicalParams 将参数编码为键有序的 JSON，忽略 csrf_token 以及 ignore 中的参数
+func canonicalParams(params map[string]interface{}, ignore []string) string {
+	filtered := make(map[string]interface{}, len(params))
+	for k, v := range params {
+		filtered[k] = v
+	}
+	delete(filtered, "csrf_token")
+	for _, k := range ignore {
+		delete(filtered, k)
+	}
+	// 统一经过一次 JSON 编解码，使录制前后的数字类型一致
+	var v interface{}
+	content, _ := json.Marshal(filtered)
+	_ = json.Unmarshal(content, &v)
+	content, _ = json.Marshal(v)
+	return string(content)
+}
+
+// cookies 还原记录中响应设置的 Cookie
+func (i *Interaction) cookies() []*http.Cookie {
+	resp := http.Response{Header: http.Header{"Set-Cookie": i.Cookies}}
+	return resp.Cookies()
+}
+
+// cassetteURL 去掉 query 并统一 weapi、eapi 等前缀，使同一接口的不同调用方式能够匹配
+func cassetteURL(url string) string {
+	u, err := urlpkg.Parse(url)
+	if err != nil {
+		return url
+	}
+	u.RawQuery = ""
+	u.Path = apiPrefix.ReplaceAllString(u.Path, "/api/")
+	return u.String()
+}
+
+func stringParams(data map[string]string) map[string]interface{} {
+	params := make(map[string]interface{}, len(data))
+	for k, v := range data {
+		params[k] = v
+	}
+	return params
+}
@@ -0,0 +1,168 @@
+package util
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestCassette_RecordReplay(t *testing.T) {
+	var hits, polls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		if r.URL.Path == "/weapi/login/qrcode/client/login" {
+			if atomic.AddInt32(&polls, 1) == 1 {
+				_, _ = w.Write([]byte(`{"code":801}`))
+				return
+			}
+			http.SetCookie(w, &http.Cookie{Name: "MUSIC_U", Value: "recorded", Path: "/"})
+			_, _ = w.Write([]byte(`{"code":803}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"code":200,"result":{"songCount":1}}`))
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	recorder := &Client{
+		BaseURLs: map[string]string{HostMusic: server.URL},
+		Cassette: NewCassette(path),
+	}
+	search := func(c *Client) (float64, []byte, error) {
+		code, body, _, err := c.CreateRequestContext(context.Background(), "POST", "https://music.163.com/api/cloudsearch/pc", map[string]string{"s": "测试", "type": "1"}, &Options{Crypto: "weapi"})
+		return code, body, err
+	}
+	checkQR := func(c *Client) (float64, []byte, error) {
+		return c.CallWeapiContext(context.Background(), "https://music.163.com/weapi/login/qrcode/client/login?csrf_token=", map[string]interface{}{"key": "unikey", "type": 1})
+	}
+
+	if code, _, err := search(recorder); err != nil || code != 200 {
+		t.Fatalf("record search: code %f, err %v", code, err)
+	}
+	if code, _, _ := checkQR(recorder); code != 801 {
+		t.Fatalf("record qr: code %f", code)
+	}
+	if code, _, _ := checkQR(recorder); code != 803 {
+		t.Fatalf("record qr: code %f", code)
+	}
+	if err := recorder.Cassette.Save(); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	cassette, err := LoadCassette(path)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	replayer := &Client{
+		Transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			t.Errorf("unexpected request in replay mode: %s", r.URL)
+			return nil, errors.New("offline")
+		}),
+		Cassette: cassette,
+	}
+	recorded := atomic.LoadInt32(&hits)
+
+	code, body, err := search(replayer)
+	if err != nil || code != 200 || string(body) != `{"code":200,"result":{"songCount":1}}` {
+		t.Fatalf("replay search: code %f, body %s, err %v", code, body, err)
+	}
+	// 相同请求按录制顺序返回
+	if code, _, err := checkQR(replayer); code != 801 || !errors.Is(err, ErrQRWaiting) {
+		t.Fatalf("replay qr: code %f, err %v", code, err)
+	}
+	if code, _, _ := checkQR(replayer); code != 803 {
+		t.Fatalf("replay qr: code %f", code)
+	}
+	if code, _, _ := checkQR(replayer); code != 803 {
+		t.Fatalf("replay qr after exhausted: code %f", code)
+	}
+	musicURL, _ := url.Parse("https://music.163.com")
+	if CookieValueByName(replayer.CookieJar().Cookies(musicURL), "MUSIC_U", "") != "recorded" {
+		t.Fatal("recorded cookies not restored")
+	}
+	if atomic.LoadInt32(&hits) != recorded {
+		t.Fatal("replay reached the server")
+	}
+
+	code, _, _, err = replayer.CreateRequestContext(context.Background(), "POST", "https://music.163.com/api/cloudsearch/pc", map[string]string{"s": "其他", "type": "1"}, &Options{Crypto: "weapi"})
+	if code != 520 || !errors.Is(err, ErrCassetteMiss) || !errors.Is(err, ErrTransport) {
+		t.Fatalf("expected cassette miss, got code %f, err %v", code, err)
+	}
+}
+
+func TestCassette_RecordSetCookiesOnly(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.SetCookie(w, &http.Cookie{Name: "NMTID", Value: "server-set", Path: "/"})
+		_, _ = w.Write([]byte(`{"code":200}`))
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	recorder := &Client{
+		BaseURLs: map[string]string{HostMusic: server.URL},
+		Cassette: NewCassette(path),
+	}
+	musicURL, _ := url.Parse("https://music.163.com/")
+	recorder.CookieJar().SetCookies(musicURL, []*http.Cookie{
+		{Name: "MUSIC_U", Value: "token-123", Path: "/"},
+		{Name: "__csrf", Value: "csrf-123", Path: "/"},
+	})
+	_, _, cookies, err := recorder.CreateRequestContext(context.Background(), "POST", "https://music.163.com/weapi/v3/song/detail", map[string]string{}, &Options{Crypto: "weapi"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 旧版本的 resCookies 仍为 CookieJar 中的 Cookie
+	if CookieValueByName(cookies, "MUSIC_U", "") != "token-123" {
+		t.Errorf("resCookies = %v", cookies)
+	}
+	if err := recorder.Cassette.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	// 只录制响应设置的 Cookie，登录凭证不会写入录制文件
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{"MUSIC_U", "token-123", "__csrf", "sDeviceId"} {
+		if strings.Contains(string(data), secret) {
+			t.Errorf("cassette contains %s:\n%s", secret, data)
+		}
+	}
+	if !strings.Contains(string(data), "server-set") {
+		t.Errorf("Set-Cookie not recorded:\n%s", data)
+	}
+
+	cassette, err := LoadCassette(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	replayer := &Client{Cassette: cassette}
+	if _, _, _, err := replayer.CreateRequestContext(context.Background(), "POST", "https://music.163.com/weapi/v3/song/detail", map[string]string{}, &Options{Crypto: "weapi"}); err != nil {
+		t.Fatal(err)
+	}
+	endpoint, _ := url.Parse("https://music.163.com/weapi/v3/song/detail")
+	if got, want := len(replayer.CookieJar().Cookies(endpoint)), len(replayer.CookieJar().Cookies(musicURL)); got != want {
+		t.Errorf("replay stored %d cookies for the endpoint, %d for /", got, want)
+	}
+}
+
+func TestCassetteURL(t *testing.T) {
+	cases := map[string]string{
+		"https://music.163.com/weapi/v3/song/detail":             "https://music.163.com/api/v3/song/detail",
+		"https://music.163.com/eapi/v3/song/detail":              "https://music.163.com/api/v3/song/detail",
+		"https://music.163.com/api/feedback/weblog?csrf_token=x": "https://music.163.com/api/feedback/weblog",
+	}
+	for in, want := range cases {
+		if got := cassetteURL(in); got != want {
+			t.Errorf("cassetteURL(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package util
+
+import (
+	"crypto/rsa"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/cookiejar"
+	"sync"
+	"time"
+)
+
+// Client 网易云音乐客户端
+//
+// 每个 Client 独立持有 CookieJar、设备ID、代理以及 UNM 配置，
+// 同一进程内可以创建多个 Client 以同时使用多个网易云账号。
+// Client 的零值即可使用，首次请求时才会初始化 CookieJar 和设备ID。
+//
+// 包级别的 CreateRequest、NewRequest、CallWeapi 等函数使用的是 DefaultClient。
+type Client struct {
+	// Proxy 代理地址，支持 http、https、socks5（可带用户名密码），为空时不使用代理。
+	// 单次请求可以通过 WithProxy 覆盖
+	Proxy string
+	// NoProxy 不经过 Proxy 的地址，逗号分隔，格式同环境变量 NO_PROXY
+	NoProxy string
+	// InsecureSkipVerify 不校验服务端证书，只在使用会替换证书的抓包代理等场景下设置，
+	// 此时代理能够读取、篡改包括登录在内的所有请求。设置了 Transport 时不生效
+	InsecureSkipVerify bool
+	// Profile 模拟的客户端，如 util.PCProfile()，为 nil 时由各 service 决定，单次请求可以通过 WithProfile 覆盖
+	Profile *ClientProfile
+	// RealIP 通过 X-Real-IP、X-Forwarded-For 发送的 IP，海外使用时可设置为中国大陆 IP 以避免搜索、播放地址等结果为空。
+	// 为 RealIPRandom 时每次请求随机选择，单次请求可以通过 WithRealIP 覆盖
+	RealIP string
+	// UNM 解灰配置，为 nil 时使用包级别的 UNMSwitch、Sources 等配置
+	UNM *UNMConfig
+	// Transport 发送请求使用的 RoundTripper，为 nil 时使用所有 Client 共享的连接池（见 NewTransport）。
+	// 设置后 Proxy 不再生效，代理需由 Transport 自行处理
+	Transport http.RoundTripper
+	// BaseURLs 按域名改写请求地址，如 {"music.163.com": "http://127.0.0.1:8080"}，
+	// 可用于将请求指向 httptest.Server 等本地服务
+	BaseURLs map[string]string
+	// WeapiPublicKey weapi 加密使用的 RSA 公钥（PEM 格式），为空时使用网易云音乐的公钥。
+	// 一般只在对接 neteasetest 等需要解密请求的本地服务时设置
+	WeapiPublicKey []byte
+	// Cassette 不为 nil 时录制或回放 CreateRequest、CallWeapi 发出的请求
+	Cassette *Cassette
+	// Interceptors 请求拦截器，按顺序包裹 CreateRequest、CallWeapi 发出的请求
+	Interceptors []Interceptor
+	// Cache 响应缓存，为 nil 时不缓存
+	Cache *Cache
+	// Retry 请求失败后的重试策略，为 nil 时不重试
+	Retry *RetryPolicy
+	// RateLimiter 限制请求频率，为 nil 时不限制，可以在多个 Client 间共享
+	RateLimiter *RateLimiter
+	// Logger 记录请求日志，为 nil 时使用 util.Logger()
+	Logger *slog.Logger
+
+	mu      sync.Mutex
+	jar     http.CookieJar
+	jarOnce sync.Once
+	device  *DeviceIdentity
+	// userID、loginAt、loginExpires、guestExpires 从登录相关的响应中记录，见 ExportSession
+	userID       int64
+	loginAt      time.Time
+	loginExpires time.Time
+	guestExpires time.Time
+}
+
+var defaultClient = &Client{}
+
+// NewClient 创建一个使用指定 CookieJar 的 Client，jar 为 nil 时使用内存 CookieJar
+func NewClient(jar http.CookieJar) *Client {
+	return &Client{jar: jar}
+}
+
+// DefaultClient 返回包级别函数所使用的默认 Client
+func DefaultClient() *Client {
+	return defaultClient
+}
+
+// orDefault 使得 nil *Client 也能直接调用方法，此时使用默认 Client
+func (c *Client) orDefault() *Client {
+	if c == nil {
+		return defaultClient
+	}
+	return c
+}
+
+// SetCookieJar 替换 Client 使用的 CookieJar，设备标识随之改为从 jar 中读取
+func (c *Client) SetCookieJar(jar http.CookieJar) {
+	c = c.orDefault()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.jar = jar
+	if jar != nil {
+		c.device = loadDevice(jar)
+	}
+}
+
+// CookieJar 返回 Client 使用的 CookieJar
+//
+// 首次调用时若未设置 CookieJar 则新建一个内存 CookieJar，并从中读取或生成设备标识（见 DeviceIdentity）
+func (c *Client) CookieJar() http.CookieJar {
+	c = c.orDefault()
+	c.jarOnce.Do(func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		if c.jar == nil {
+			// 为空时才新建一个jar对象
+			jar, _ := cookiejar.New(nil)
+			c.jar = jar
+		}
+		if c.device == nil {
+			c.device = loadDevice(c.jar)
+		}
+	})
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.jar
+}
+
+// SetDeviceId 指定 Client 在 eapi 请求中使用的设备ID，等同于只设置 DeviceId 的 SetDevice
+func (c *Client) SetDeviceId(deviceId string) {
+	c.SetDevice(&DeviceIdentity{DeviceId: deviceId})
+}
+
+// DeviceId 返回 Client 在 eapi 请求中使用的设备ID
+func (c *Client) DeviceId() string {
+	return c.Device().DeviceId
+}
+
+// unmConfig 返回 Client 生效的 UNM 配置
+func (c *Client) unmConfig() UNMConfig {
+	if c.UNM != nil {
+		return *c.UNM
+	}
+	return globalUNMConfig()
+}
+
+// weapiPublicKey 返回 weapi 加密使用的 RSA 公钥，WeapiPublicKey 无法解析为 RSA 公钥时返回错误
+func (c *Client) weapiPublicKey() (*rsa.PublicKey, error) {
+	if len(c.WeapiPublicKey) == 0 {
+		return defaultPublicKey, nil
+	}
+	pub, err := parseRSAPublicKey(c.WeapiPublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("netease: invalid Client.WeapiPublicKey: %w", err)
+	}
+	return pub, nil
+}
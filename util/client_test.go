@@ -0,0 +1,34 @@
+package util
+
+import (
+	"net/url"
+	"testing"
+)
+
+func TestClient_Isolation(t *testing.T) {
+	a, b := NewClient(nil), &Client{}
+	a.SetDeviceId("device-a")
+	b.SetDeviceId("device-b")
+	if a.DeviceId() == b.DeviceId() {
+		t.Fatalf("device id shared between clients: %s", a.DeviceId())
+	}
+
+	AddCookiesToJar(a.CookieJar(), map[string]string{"MUSIC_U": "a"}, "https://music.163.com")
+	u, _ := url.Parse("https://music.163.com")
+	if v := CookieValueByName(b.CookieJar().Cookies(u), "MUSIC_U", ""); v != "" {
+		t.Fatalf("cookie leaked into another client: %s", v)
+	}
+	if CheckSDeviceId(b.CookieJar()) == "" {
+		t.Fatal("sDeviceId not initialized")
+	}
+}
+
+func TestClient_NilUsesDefault(t *testing.T) {
+	var c *Client
+	if c.CookieJar() != GetGlobalCookieJar() {
+		t.Fatal("nil client should use the global cookie jar")
+	}
+	if c.DeviceId() != DefaultClient().DeviceId() {
+		t.Fatal("nil client should use the default device id")
+	}
+}
@@ -3,8 +3,8 @@ package util
 import (
 	"crypto/md5"
 	"encoding/hex"
+	"errors"
 	"fmt"
-	"log"
 	"math/rand"
 	math_rand "math/rand/v2"
 	"net/http"
@@ -50,10 +50,7 @@ func Ternary[T any](cond bool, a, b T) T {
 
 // 获取cookiejar中存储的csrf_token
 func GetCsrfToken(cookieJar http.CookieJar) string {
-	musicURL, err := url.Parse("https://music.163.com")
-	if err != nil {
-		log.Fatalf("Failed to parse music URL: %v", err)
-	}
+	musicURL, _ := url.Parse("https://music.163.com")
 	csrfToken := ""
 	if cookieJar != nil {
 		for _, cookie := range cookieJar.Cookies(musicURL) {
@@ -67,14 +64,16 @@ func GetCsrfToken(cookieJar http.CookieJar) string {
 }
 
 // 将 cookies 添加到指定的 CookieJar 中
-func AddCookiesToJar(jar http.CookieJar, cookies map[string]string, targetURLStr string) {
+//
+// jar 为 nil 或 targetURLStr 无法解析时返回错误
+func AddCookiesToJar(jar http.CookieJar, cookies map[string]string, targetURLStr string) error {
 	if jar == nil {
-		log.Fatal("CookieJar的值不能为 nil")
+		return errors.New("CookieJar的值不能为 nil")
 	}
 
 	targetURL, err := url.Parse(targetURLStr)
 	if err != nil {
-		log.Fatalf("无法解析 URL '%s': %v", targetURLStr, err)
+		return fmt.Errorf("无法解析 URL '%s': %w", targetURLStr, err)
 	}
 
 	var cookiesToSet []*http.Cookie
@@ -91,6 +90,7 @@ func AddCookiesToJar(jar http.CookieJar, cookies map[string]string, targetURLStr
 	if len(cookiesToSet) > 0 {
 		jar.SetCookies(targetURL, cookiesToSet)
 	}
+	return nil
 }
 
 func stringToEnt(s string) string {
@@ -1,6 +1,7 @@
 package util
 
 import (
+	"slices"
 	"sync"
 
 	"github.com/cnsilvan/UnblockNeteaseMusic/common"
@@ -20,6 +21,40 @@ var (
 	providerInited = sync.Once{}
 )
 
+// UNMConfig 单个 Client 的解灰配置，字段含义与包级别的同名变量一致
+type UNMConfig struct {
+	Enable             bool
+	Sources            []string
+	ForceBestQuality   bool
+	SearchLimit        int
+	EnableLocalVip     bool
+	UnlockSoundEffects bool
+	QQCookieFile       string
+}
+
+func (cfg UNMConfig) equal(other UNMConfig) bool {
+	return cfg.Enable == other.Enable &&
+		slices.Equal(cfg.Sources, other.Sources) &&
+		cfg.ForceBestQuality == other.ForceBestQuality &&
+		cfg.SearchLimit == other.SearchLimit &&
+		cfg.EnableLocalVip == other.EnableLocalVip &&
+		cfg.UnlockSoundEffects == other.UnlockSoundEffects &&
+		cfg.QQCookieFile == other.QQCookieFile
+}
+
+// globalUNMConfig 由包级别变量组成的 UNM 配置，供默认 Client 使用
+func globalUNMConfig() UNMConfig {
+	return UNMConfig{
+		Enable:             UNMSwitch,
+		Sources:            Sources,
+		ForceBestQuality:   ForceBestQuality,
+		SearchLimit:        SearchLimit,
+		EnableLocalVip:     EnableLocalVip,
+		UnlockSoundEffects: UnlockSoundEffects,
+		QQCookieFile:       QQCookieFile,
+	}
+}
+
 func ConfigInit() {
 	providerInited.Do(func() {
 		ConfigReload()
@@ -27,12 +62,41 @@ func ConfigInit() {
 }
 
 func ConfigReload() {
-	common.Source = Sources
-	config.ForceBestQuality = &ForceBestQuality
-	config.SearchLimit = &SearchLimit
-	config.EnableLocalVip = &EnableLocalVip
-	config.UnlockSoundEffects = &UnlockSoundEffects
-	config.QQCookieFile = &QQCookieFile
+	unmMu.Lock()
+	defer unmMu.Unlock()
+	applyUNMConfig(globalUNMConfig())
+}
+
+var (
+	// UNM 的配置是全局的，不同 Client 的配置需要串行切换
+	unmMu      sync.RWMutex
+	appliedUNM *UNMConfig
+)
+
+// applyUNMConfig 将配置写入 UNM，调用方需持有 unmMu 写锁
+func applyUNMConfig(cfg UNMConfig) {
+	common.Source = cfg.Sources
+	config.ForceBestQuality = &cfg.ForceBestQuality
+	config.SearchLimit = &cfg.SearchLimit
+	config.EnableLocalVip = &cfg.EnableLocalVip
+	config.UnlockSoundEffects = &cfg.UnlockSoundEffects
+	config.QQCookieFile = &cfg.QQCookieFile
 
 	provider.Init()
+	appliedUNM = &cfg
+}
+
+// acquireUNM 确保 UNM 当前使用的是 cfg，并在返回的 release 被调用前保持该配置不被其他 Client 切换
+func acquireUNM(cfg UNMConfig) (release func()) {
+	unmMu.RLock()
+	if appliedUNM != nil && appliedUNM.equal(cfg) {
+		return unmMu.RUnlock
+	}
+	unmMu.RUnlock()
+
+	unmMu.Lock()
+	if appliedUNM == nil || !appliedUNM.equal(cfg) {
+		applyUNMConfig(cfg)
+	}
+	return unmMu.Unlock
 }
@@ -1,6 +1,8 @@
 package util
 
 import (
+	"bytes"
+	"crypto/aes"
 	"crypto/md5"
 	"crypto/rand"
 	"crypto/rsa"
@@ -9,6 +11,7 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"encoding/pem"
+	"errors"
 	"fmt"
 	"math/big"
 	math_rand "math/rand/v2"
@@ -26,6 +29,7 @@ var linuxapiKey = []byte("rFgB&h#%2?^eDg:Q")
 var stdChars = []byte("ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789")
 var publicKey = []byte("-----BEGIN PUBLIC KEY-----\nMIGfMA0GCSqGSIb3DQEBAQUAA4GNADCBiQKBgQDgtQn2JZ34ZC28NWYpAUd98iZ37BUrX/aKzmFbt7clFSs6sXqHauqKWqdtLkF2KexO40H1YTX8z2lSgBBOAxLsvaklV8k4cBFK9snQXE9/DDaFt6Rr7iVZMldczhC0JNgTz+SHXT6CBHuX3e9SdB1Ua44oncaTWz7OBGLbCiK45wIDAQAB\n-----END PUBLIC KEY-----")
 var eapiKey = []byte("e82ckenh8dichen8")
+var eapiSeparator = "-36cd479b6b5-"
 
 func aesEncryptCBC(buffer []byte, key []byte, ivv []byte) []byte {
 	dst, _ := openssl.AesCBCEncrypt(buffer, key, ivv, openssl.PKCS7_PADDING)
@@ -50,6 +54,13 @@ func aesEncryptECB(buffer []byte, key []byte) []byte {
 	//fmt.Println(string(dst)) // 123456
 }
 
+func aesDecryptECB(buffer []byte, key []byte) ([]byte, error) {
+	if len(buffer) == 0 || len(buffer)%aes.BlockSize != 0 {
+		return nil, errors.New("ciphertext is not a multiple of the block size")
+	}
+	return openssl.AesECBDecrypt(buffer, key, openssl.PKCS7_PADDING)
+}
+
 func NewLen16Rand() ([]byte, []byte) {
 	randByte := make([]byte, 16)
 	randByteReverse := make([]byte, 16)
@@ -70,19 +81,36 @@ func aesEncrypt(buffer []byte, mod string, key []byte, ivv []byte) []byte {
 	return nil
 }
 
-func rsaEncrypt(buffer []byte, key []byte) []byte {
-	buffers := make([]byte, 128-16, 128)
-	buffers = append(buffers, buffer...)
+// parseRSAPublicKey 解析 PEM 格式的 RSA 公钥
+func parseRSAPublicKey(key []byte) (*rsa.PublicKey, error) {
 	block, _ := pem.Decode(key)
 	if block == nil {
-		return nil
+		return nil, errors.New("rsa public key is not PEM encoded")
 	}
 	pubInterface, err := x509.ParsePKIXPublicKey(block.Bytes)
 	if err != nil {
-		fmt.Println(err.Error())
+		return nil, fmt.Errorf("parse rsa public key: %w", err)
 	}
 	// 类型断言
-	pub := pubInterface.(*rsa.PublicKey)
+	pub, ok := pubInterface.(*rsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("public key is %T, not RSA", pubInterface)
+	}
+	return pub, nil
+}
+
+// defaultPublicKey 网易云音乐的公钥，内置的 PEM 总能解析成功
+var defaultPublicKey = func() *rsa.PublicKey {
+	pub, err := parseRSAPublicKey(publicKey)
+	if err != nil {
+		panic(err)
+	}
+	return pub
+}()
+
+func rsaEncrypt(buffer []byte, pub *rsa.PublicKey) []byte {
+	buffers := make([]byte, 128-16, 128)
+	buffers = append(buffers, buffer...)
 
 	// 加密 因为网易采用的是无padding加密故直接进行计算
 	c := new(big.Int).SetBytes([]byte(buffers))
@@ -97,6 +125,11 @@ func rsaEncrypt(buffer []byte, key []byte) []byte {
 }
 
 func Weapi(data map[string]string) map[string]string {
+	return weapiWithKey(data, defaultPublicKey)
+}
+
+// weapiWithKey 使用指定的 RSA 公钥进行 weapi 加密
+func weapiWithKey(data map[string]string, rsaPublicKey *rsa.PublicKey) map[string]string {
 	text, _ := json.Marshal(data)
 	//fmt.Println(string(text))
 	secretKey, reSecretKey := NewLen16Rand()
@@ -104,7 +137,7 @@ func Weapi(data map[string]string) map[string]string {
 	//reSecretKey,_=hex.DecodeString("72334f6379767a663948625549325435")
 	weapiType := make(map[string]string, 2)
 	weapiType["params"] = base64.StdEncoding.EncodeToString(aesEncrypt([]byte(base64.StdEncoding.EncodeToString(aesEncrypt(text, "cbc", presetKey, iv))), "cbc", reSecretKey, iv))
-	weapiType["encSecKey"] = hex.EncodeToString(rsaEncrypt(secretKey, publicKey))
+	weapiType["encSecKey"] = hex.EncodeToString(rsaEncrypt(secretKey, rsaPublicKey))
 	return weapiType
 }
 
@@ -115,20 +148,92 @@ func Linuxapi(data map[string]interface{}) map[string]string {
 	return linuxapiType
 }
 
+// LinuxapiDecrypt 解密 Linuxapi 加密的 eparams，返回转发的 method、url 与 params
+func LinuxapiDecrypt(eparams string) (map[string]interface{}, error) {
+	text, err := decryptECBHex(eparams, linuxapiKey)
+	if err != nil {
+		return nil, fmt.Errorf("linuxapi: %w", err)
+	}
+	data, err := unmarshalParams(text)
+	if err != nil {
+		return nil, fmt.Errorf("linuxapi: %w", err)
+	}
+	return data, nil
+}
+
 func Eapi(url string, data map[string]interface{}) map[string]string {
 	textByte, _ := json.Marshal(data)
-	message := "nobody" + url + "use" + string(textByte) + "md5forencrypt"
-	h := md5.New()
-	h.Write([]byte(message))
-	digest := hex.EncodeToString(h.Sum(nil))
-	dd := url + "-36cd479b6b5-" + string(textByte) + "-36cd479b6b5-" + digest
+	digest := eapiDigest(url, string(textByte))
+	dd := url + eapiSeparator + string(textByte) + eapiSeparator + digest
 	eapiType := make(map[string]string, 1)
 	eapiType["params"] = strings.ToUpper(hex.EncodeToString(aesEncrypt([]byte(dd), "ecb", eapiKey, nil)))
 	return eapiType
 }
 
+// EapiDecrypt 解密 Eapi 加密的 params，返回签名的接口地址与参数，并校验其中的 md5 摘要
+func EapiDecrypt(params string) (url string, data map[string]interface{}, err error) {
+	text, err := decryptECBHex(params, eapiKey)
+	if err != nil {
+		return "", nil, fmt.Errorf("eapi: %w", err)
+	}
+	// 参数中可能含有分隔符，接口地址取第一个分隔符之前的部分，摘要取最后一个分隔符之后的部分
+	url, rest, ok := strings.Cut(string(text), eapiSeparator)
+	i := strings.LastIndex(rest, eapiSeparator)
+	if !ok || i < 0 {
+		return "", nil, errors.New("eapi: malformed params")
+	}
+	body, digest := rest[:i], rest[i+len(eapiSeparator):]
+	if eapiDigest(url, body) != digest {
+		return "", nil, errors.New("eapi: digest mismatch")
+	}
+	data, err = unmarshalParams([]byte(body))
+	if err != nil {
+		return "", nil, fmt.Errorf("eapi: %w", err)
+	}
+	return url, data, nil
+}
+
+// EapiResponseDecrypt 解密 e_r=true 时 eapi 接口返回的加密响应体
+func EapiResponseDecrypt(body []byte) ([]byte, error) {
+	text, err := aesDecryptECB(body, eapiKey)
+	if err != nil {
+		return nil, fmt.Errorf("eapi response: %w", err)
+	}
+	return text, nil
+}
+
+func eapiDigest(url, text string) string {
+	h := md5.New()
+	h.Write([]byte("nobody" + url + "use" + text + "md5forencrypt"))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func decryptECBHex(s string, key []byte) ([]byte, error) {
+	encrypted, err := hex.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("decode hex: %w", err)
+	}
+	return aesDecryptECB(encrypted, key)
+}
+
+// unmarshalParams 解析解密后的参数，数字保留为 json.Number 以免大整数丢失精度
+func unmarshalParams(text []byte) (map[string]interface{}, error) {
+	params := make(map[string]interface{})
+	decoder := json.NewDecoder(bytes.NewReader(text))
+	decoder.UseNumber()
+	if err := decoder.Decode(&params); err != nil {
+		return nil, fmt.Errorf("unmarshal params: %w", err)
+	}
+	return params, nil
+}
+
 // 对网易云api的参数进行加密处理
 func ApiParamsEncode(data map[string]interface{}) (map[string]string, error) {
+	return apiParamsEncodeWithKey(data, defaultPublicKey)
+}
+
+// apiParamsEncodeWithKey 使用指定的 RSA 公钥对参数进行加密处理
+func apiParamsEncodeWithKey(data map[string]interface{}, rsaPublicKey *rsa.PublicKey) (map[string]string, error) {
 	jsonData, err := json.Marshal(data)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal data to JSON: %w", err)
@@ -139,7 +244,7 @@ func ApiParamsEncode(data map[string]interface{}) (map[string]string, error) {
 	secondCiphertext := aesEncrypt(
 		[]byte(firstCiphertextBase64), "cbc", secondSecretKey, iv)
 	finalParams := base64.StdEncoding.EncodeToString(secondCiphertext)
-	encryptedSecretKey := rsaEncrypt(secretKey, publicKey)
+	encryptedSecretKey := rsaEncrypt(secretKey, rsaPublicKey)
 	finalEncSecKey := hex.EncodeToString(encryptedSecretKey)
 	encodedParams := map[string]string{
 		"params":    finalParams,
@@ -162,11 +267,28 @@ func CheckSDeviceId(cookieJar http.CookieJar) string {
 	return ""
 }
 
-// GenerateSDeviceId 生成一个随机的 sDeviceId
+// sDeviceIdPrefix 网页端 sDeviceId 的前缀，由网易易盾的设备指纹生成
+const sDeviceIdPrefix = "YD-"
+
+// GenerateSDeviceId 生成一个与网页端格式相同的 sDeviceId，即 "YD-" 加32位 base64 字符
 func GenerateSDeviceId() string {
-	randomNum := math_rand.IntN(1000000)
-	sDeviceId := fmt.Sprintf("unknown-%d", randomNum)
-	return sDeviceId
+	b := make([]byte, 24)
+	_, _ = rand.Read(b)
+	return sDeviceIdPrefix + base64.StdEncoding.EncodeToString(b)
+}
+
+// anonymousIDKey 游客注册时混淆设备ID使用的密钥
+var anonymousIDKey = []byte("3go8&$8*3*3h0k(2)2")
+
+// AnonymousUsername 返回游客注册（/api/register/anonimous）使用的 username：
+// base64("<deviceId> <base64(md5(deviceId 与密钥逐字节异或))>")
+func AnonymousUsername(deviceId string) string {
+	xored := make([]byte, len(deviceId))
+	for i := 0; i < len(deviceId); i++ {
+		xored[i] = deviceId[i] ^ anonymousIDKey[i%len(anonymousIDKey)]
+	}
+	digest := md5.Sum(xored)
+	return base64.StdEncoding.EncodeToString([]byte(deviceId + " " + base64.StdEncoding.EncodeToString(digest[:])))
 }
 
 // GenerateChainID 生成chainID
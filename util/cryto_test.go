@@ -0,0 +1,83 @@
+package util
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestLinuxapiDecrypt(t *testing.T) {
+	data := map[string]interface{}{
+		"method": "POST",
+		"url":    "https://music.163.com/api/song/enhance/player/url",
+		"params": map[string]interface{}{"ids": "[1962165890]", "br": 999000},
+	}
+	got, err := LinuxapiDecrypt(Linuxapi(data)["eparams"])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got["method"] != "POST" || got["url"] != data["url"] {
+		t.Fatalf("unexpected result: %v", got)
+	}
+	params := got["params"].(map[string]interface{})
+	if params["ids"] != "[1962165890]" || params["br"] != json.Number("999000") {
+		t.Fatalf("unexpected params: %v", params)
+	}
+
+	if _, err := LinuxapiDecrypt("not hex"); err == nil {
+		t.Fatal("expected error for malformed eparams")
+	}
+	if _, err := LinuxapiDecrypt("ABCDEF"); err == nil {
+		t.Fatal("expected error for truncated eparams")
+	}
+}
+
+func TestEapiDecrypt(t *testing.T) {
+	data := map[string]interface{}{"id": "405998841", "n": 1000, "header": map[string]string{"os": "ios"}}
+	url, got, err := EapiDecrypt(Eapi("/api/v3/song/detail", data)["params"])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if url != "/api/v3/song/detail" || got["id"] != "405998841" || got["n"] != json.Number("1000") {
+		t.Fatalf("unexpected result: %s %v", url, got)
+	}
+	if header := got["header"].(map[string]interface{}); header["os"] != "ios" {
+		t.Fatalf("unexpected header: %v", header)
+	}
+
+	// 参数中含有分隔符
+	url, got, err = EapiDecrypt(Eapi("/api/search/get", map[string]interface{}{"s": "a" + eapiSeparator + "b"})["params"])
+	if err != nil || url != "/api/search/get" || got["s"] != "a"+eapiSeparator+"b" {
+		t.Fatalf("separator in params: %s %v %v", url, got, err)
+	}
+
+	// 篡改参数后 md5 摘要校验失败
+	text := "/api/v3/song/detail" + eapiSeparator + `{"id":"1"}` + eapiSeparator + eapiDigest("/api/v3/song/detail", `{"id":"2"}`)
+	tampered := strings.ToUpper(hex.EncodeToString(aesEncrypt([]byte(text), "ecb", eapiKey, nil)))
+	if _, _, err := EapiDecrypt(tampered); err == nil || !strings.Contains(err.Error(), "digest") {
+		t.Fatalf("expected digest mismatch, got %v", err)
+	}
+}
+
+func TestEapiResponseDecrypt(t *testing.T) {
+	body := []byte(`{"code":200,"data":[{"id":405998841}]}`)
+	got, err := EapiResponseDecrypt(aesEncrypt(body, "ecb", eapiKey, nil))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(got) != string(body) {
+		t.Fatalf("unexpected result: %s", got)
+	}
+
+	if _, err := EapiResponseDecrypt(body); err == nil {
+		t.Fatal("expected error for plain response")
+	}
+}
+
+func TestAnonymousUsername(t *testing.T) {
+	// 与 NeteaseCloudMusicApi 中 register_anonimous 对 "NMUSIC" 编码的结果一致
+	if got := AnonymousUsername("NMUSIC"); got != "Tk1VU0lDIGdtVG82R2lvNEZoRWY5MFZqZzhPenc9PQ==" {
+		t.Fatalf("username = %s", got)
+	}
+}
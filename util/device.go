@@ -0,0 +1,129 @@
+package util
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// deviceCookieLifetime 设备标识 Cookie 的有效期，使持久化的 CookieJar 重启后仍使用同一设备
+const deviceCookieLifetime = 10 * 365 * 24 * time.Hour
+
+// DeviceIdentity 设备标识
+//
+// 同一设备应当一直使用相同的标识，频繁变化容易触发风控。Client 首次使用时从 CookieJar 中读取，
+// 缺少的部分会生成后以长期有效的 Cookie 写回 CookieJar，因此使用 cookiejar.NewFileJar 等持久化的
+// CookieJar 时重启后仍是同一设备
+type DeviceIdentity struct {
+	// DeviceId eapi 请求使用的设备ID
+	DeviceId string `json:"deviceId"`
+	// SDeviceId 网页端的设备ID
+	SDeviceId string `json:"sDeviceId"`
+	// NtesNUID 即 _ntes_nuid
+	NtesNUID string `json:"ntesNuid"`
+	NMTID    string `json:"nmtid"`
+}
+
+// NewDeviceIdentity 生成一个新的设备标识
+func NewDeviceIdentity() *DeviceIdentity {
+	return &DeviceIdentity{
+		DeviceId:  GenerateDeviceId(),
+		SDeviceId: GenerateSDeviceId(),
+		NtesNUID:  CreateNtesUID("https://music.163.com/", 1920, 1080, chooseUserAgent("pc")),
+		NMTID:     randomHex(16),
+	}
+}
+
+// GenerateDeviceId 生成一个与客户端格式相同的设备ID，即52位大写十六进制字符串
+func GenerateDeviceId() string {
+	return strings.ToUpper(randomHex(26))
+}
+
+func randomHex(n int) string {
+	b := make([]byte, n)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// deviceCookieNames 设备标识在 CookieJar 中对应的 Cookie 名称
+var deviceCookieNames = [...]string{"deviceId", "sDeviceId", "_ntes_nuid", "NMTID"}
+
+func (d *DeviceIdentity) fields() [len(deviceCookieNames)]*string {
+	return [...]*string{&d.DeviceId, &d.SDeviceId, &d.NtesNUID, &d.NMTID}
+}
+
+// cookies 返回保存设备标识的 Cookie，对 music.163.com 及其子域名有效
+func (d *DeviceIdentity) cookies() []*http.Cookie {
+	expires := time.Now().Add(deviceCookieLifetime)
+	cookies := make([]*http.Cookie, 0, len(deviceCookieNames))
+	for i, field := range d.fields() {
+		if *field == "" {
+			continue
+		}
+		cookies = append(cookies, &http.Cookie{Name: deviceCookieNames[i], Value: *field, Path: "/", Domain: HostMusic, Expires: expires})
+	}
+	return cookies
+}
+
+// loadDevice 从 jar 中读取设备标识，缺少的部分生成后写回 jar
+func loadDevice(jar http.CookieJar) *DeviceIdentity {
+	u, _ := url.Parse("https://" + HostMusic)
+	cookies := jar.Cookies(u)
+	d, generated := &DeviceIdentity{}, NewDeviceIdentity()
+	var missing bool
+	for i, field := range d.fields() {
+		*field = CookieValueByName(cookies, deviceCookieNames[i], "")
+		// 旧版本生成的 sDeviceId 是 unknown-NNNNNN 形式的占位值，视为缺失重新生成
+		if *field == "" || (deviceCookieNames[i] == "sDeviceId" && !strings.HasPrefix(*field, sDeviceIdPrefix)) {
+			*field = *generated.fields()[i]
+			missing = true
+		}
+	}
+	if missing {
+		jar.SetCookies(u, d.cookies())
+	}
+	return d
+}
+
+// saveDevice 将设备标识写入 jar
+func saveDevice(jar http.CookieJar, d *DeviceIdentity) {
+	u, _ := url.Parse("https://" + HostMusic)
+	jar.SetCookies(u, d.cookies())
+}
+
+// Device 返回 Client 使用的设备标识
+func (c *Client) Device() *DeviceIdentity {
+	c = c.orDefault()
+	c.CookieJar()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	d := *c.device
+	return &d
+}
+
+// SetDevice 指定 Client 使用的设备标识并写入 CookieJar，d 中为空的字段保持不变
+func (c *Client) SetDevice(d *DeviceIdentity) {
+	c = c.orDefault()
+	jar := c.CookieJar()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	current := c.device.fields()
+	for i, field := range d.fields() {
+		if *field != "" {
+			*current[i] = *field
+		}
+	}
+	saveDevice(jar, c.device)
+}
+
+// RotateDevice 为 Client 生成新的设备标识并写入 CookieJar，返回新的设备标识
+//
+// 更换设备相当于在新设备上使用，通常需要重新登录
+func (c *Client) RotateDevice() *DeviceIdentity {
+	d := NewDeviceIdentity()
+	c.SetDevice(d)
+	return d
+}
@@ -0,0 +1,175 @@
+package util
+
+import (
+	"context"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+// recordingJar 记录写入的 Cookie，用于检查是否设置了过期时间
+type recordingJar struct {
+	http.CookieJar
+	set []*http.Cookie
+}
+
+func (j *recordingJar) SetCookies(u *url.URL, cookies []*http.Cookie) {
+	j.set = append(j.set, cookies...)
+	j.CookieJar.SetCookies(u, cookies)
+}
+
+func TestNewDeviceIdentity(t *testing.T) {
+	d := NewDeviceIdentity()
+	checks := map[string]*regexp.Regexp{
+		d.DeviceId:  regexp.MustCompile(`^[0-9A-F]{52}$`),
+		d.SDeviceId: regexp.MustCompile(`^YD-[A-Za-z0-9+/]{32}$`),
+		d.NtesNUID:  regexp.MustCompile(`^[0-9a-f]{32}$`),
+		d.NMTID:     regexp.MustCompile(`^[0-9a-f]{32}$`),
+	}
+	for v, re := range checks {
+		if !re.MatchString(v) {
+			t.Errorf("%q does not match %s", v, re)
+		}
+	}
+	if NewDeviceIdentity().DeviceId == d.DeviceId {
+		t.Error("device id not random")
+	}
+}
+
+func TestClient_DevicePersistence(t *testing.T) {
+	inner, _ := cookiejar.New(nil)
+	jar := &recordingJar{CookieJar: inner}
+
+	d := NewClient(jar).Device()
+	if len(jar.set) != len(deviceCookieNames) {
+		t.Fatalf("unexpected cookies written: %v", jar.set)
+	}
+	for _, c := range jar.set {
+		if c.Expires.Before(time.Now().Add(365 * 24 * time.Hour)) {
+			t.Errorf("cookie %s is not persistent", c.Name)
+		}
+	}
+
+	// 同一个 CookieJar 即同一设备
+	jar.set = nil
+	if again := NewClient(jar).Device(); *again != *d {
+		t.Fatalf("identity not reused: %+v, %+v", again, d)
+	}
+	if len(jar.set) != 0 {
+		t.Fatalf("identity rewritten: %v", jar.set)
+	}
+
+	// 已有 sDeviceId 的 CookieJar 保留原来的值
+	existing := "YD-M6Lp2aNKs0NARlQVRAKRfy3+p7MmVJ6J"
+	old, _ := cookiejar.New(nil)
+	_ = AddCookiesToJar(old, map[string]string{"sDeviceId": existing}, "https://music.163.com")
+	if got := NewClient(old).Device().SDeviceId; got != existing {
+		t.Fatalf("existing sDeviceId replaced: %s", got)
+	}
+
+	// 旧版本的占位值重新生成并写回 CookieJar
+	legacy, _ := cookiejar.New(nil)
+	_ = AddCookiesToJar(legacy, map[string]string{"sDeviceId": "unknown-42"}, "https://music.163.com")
+	got := NewClient(legacy).Device().SDeviceId
+	if !strings.HasPrefix(got, "YD-") {
+		t.Fatalf("legacy sDeviceId kept: %s", got)
+	}
+	if again := NewClient(legacy).Device().SDeviceId; again != got {
+		t.Fatalf("regenerated sDeviceId not persisted: %s, %s", again, got)
+	}
+}
+
+func TestClient_RotateDevice(t *testing.T) {
+	var deviceId, nuid string
+	client := &Client{Transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		_ = r.ParseForm()
+		if _, data, err := EapiDecrypt(r.PostForm.Get("params")); err == nil {
+			header, _ := data["header"].(map[string]interface{})
+			deviceId, _ = header["deviceId"].(string)
+		}
+		if c, err := r.Cookie("_ntes_nuid"); err == nil {
+			nuid = c.Value
+		}
+		return &http.Response{StatusCode: 200, Header: http.Header{}, Body: newBody(`{"code":200}`), Request: r}, nil
+	})}
+	request := func() {
+		if _, _, _, err := client.CreateRequestContext(context.Background(), "POST", "https://interface.music.163.com/eapi/v3/song/detail", map[string]string{}, &Options{Crypto: "eapi", Url: "/api/v3/song/detail"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	before := client.Device()
+	request()
+	if deviceId != before.DeviceId || nuid != before.NtesNUID {
+		t.Fatalf("identity not used: deviceId %q, _ntes_nuid %q, want %+v", deviceId, nuid, before)
+	}
+
+	after := client.RotateDevice()
+	if after.DeviceId == before.DeviceId || after.NMTID == before.NMTID {
+		t.Fatalf("identity not rotated: %+v", after)
+	}
+	request()
+	if deviceId != after.DeviceId || nuid != after.NtesNUID {
+		t.Fatalf("rotated identity not used: deviceId %q, _ntes_nuid %q, want %+v", deviceId, nuid, after)
+	}
+	if got := NewClient(client.CookieJar()).Device(); *got != *after {
+		t.Fatalf("rotated identity not saved to the jar: %+v", got)
+	}
+
+	// 更换 CookieJar 即更换设备
+	jar, _ := cookiejar.New(nil)
+	client.SetCookieJar(jar)
+	if client.DeviceId() == after.DeviceId {
+		t.Fatal("device not switched with the cookie jar")
+	}
+}
+
+func TestClient_DeviceIdFromCookies(t *testing.T) {
+	var header map[string]interface{}
+	var cookieDeviceId string
+	client := &Client{Transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		_ = r.ParseForm()
+		if _, data, err := EapiDecrypt(r.PostForm.Get("params")); err == nil {
+			header, _ = data["header"].(map[string]interface{})
+		}
+		cookieDeviceId = ""
+		if c, err := r.Cookie("deviceId"); err == nil {
+			cookieDeviceId = c.Value
+		}
+		return &http.Response{StatusCode: 200, Header: http.Header{}, Body: newBody(`{"code":200}`), Request: r}, nil
+	})}
+	request := func(options *Options) {
+		t.Helper()
+		options.Crypto, options.Url = "eapi", "/api/v3/song/detail"
+		if _, _, _, err := client.CreateRequestContext(context.Background(), "POST", "https://interface.music.163.com/eapi/v3/song/detail", map[string]string{}, options); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// 通过 Options.Cookies 指定
+	request(&Options{Cookies: []*http.Cookie{{Name: "deviceId", Value: "OPTIONS-DEVICE"}, {Name: "osver", Value: "16.0"}}})
+	if header["deviceId"] != "OPTIONS-DEVICE" || header["osver"] != "16.0" || cookieDeviceId != "OPTIONS-DEVICE" {
+		t.Fatalf("options cookies: header %v, cookie %q", header, cookieDeviceId)
+	}
+
+	// 拦截器修改 Call.Cookies 时 deviceId 与其他设备信息一同生效
+	client.Interceptors = []Interceptor{func(ctx context.Context, call *Call, next Invoker) (*Reply, error) {
+		call.Cookies = append(call.Cookies, &http.Cookie{Name: "deviceId", Value: "INTERCEPTED"}, &http.Cookie{Name: "osver", Value: "17.0"})
+		return next(ctx, call)
+	}}
+	request(&Options{})
+	if header["deviceId"] != "INTERCEPTED" || header["osver"] != "17.0" || cookieDeviceId != "INTERCEPTED" {
+		t.Fatalf("intercepted cookies: header %v, cookie %q", header, cookieDeviceId)
+	}
+
+	// 未指定时使用 Client 的设备ID
+	client.Interceptors = nil
+	request(&Options{})
+	if want := client.Device().DeviceId; header["deviceId"] != want || cookieDeviceId != want {
+		t.Fatalf("default device id: header %v, cookie %q, want %s", header, cookieDeviceId, want)
+	}
+}
@@ -0,0 +1,80 @@
+package util
+
+import (
+	urlpkg "net/url"
+	"regexp"
+	"strings"
+)
+
+// apiPrefix 匹配 /api/、/weapi/、/eapi/ 等接口前缀
+var apiPrefix = regexp.MustCompile(`/\w*api/`)
+
+// writeAPIPrefixes 会修改账号数据的接口，重试可能导致重复提交，也不能缓存
+var writeAPIPrefixes = []string{
+	"/api/feedback/weblog",
+	"/api/point/dailyTask",
+	"/api/playlist/manipulate/tracks",
+	"/api/playlist/track/add",
+	"/api/playlist/track/delete",
+	"/api/playlist/order/update",
+	"/api/playlist/create",
+	"/api/playlist/remove",
+	"/api/playlist/delete",
+	"/api/playlist/desc/update",
+	"/api/playlist/tags/update",
+	"/api/playlist/update/name",
+	"/api/batch",
+	"/api/ordering/web/digital",
+	"/api/cloud/del",
+	"/api/share/friends/resource",
+	"/api/activate/initProfile",
+	"/api/radio/like",
+	"/api/radio/trash/add",
+	"/api/resource/comments/",
+	"/api/v1/comment/",
+	"/api/event/forward",
+	"/api/event/delete",
+	"/api/msg/private/send",
+	"/api/user/follow/",
+	"/api/user/delfollow/",
+	"/api/user/profile/update",
+	"/api/user/replaceCellphone",
+	"/api/register/",
+	"/api/sms/captcha/sent",
+	"/api/logout",
+}
+
+// writeAPISuffix 收藏、点赞等按操作名拼接地址的接口，如 /api/album/sub、/api/resource/like
+var writeAPISuffix = regexp.MustCompile(`/(sub|unsub|subscribe|unsubscribe|like|unlike)$`)
+
+// IsWriteAPI 判断 url 对应的接口是否会修改账号数据
+//
+// url 可以是完整地址或路径，weapi、eapi 等前缀会被统一为 /api/
+func IsWriteAPI(url string) bool {
+	path := apiPath(url)
+	for _, prefix := range writeAPIPrefixes {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+	return writeAPISuffix.MatchString(path)
+}
+
+// isAuthAPI 判断是否是登录、注册等与账号凭证相关的接口
+func isAuthAPI(path string) bool {
+	for _, s := range []string{"/login", "/logout", "/register", "/sms/", "/token/refresh", "/replaceCellphone"} {
+		if strings.Contains(path, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// apiPath 返回 url 的路径部分，并将 weapi、eapi 等前缀统一为 /api/
+func apiPath(url string) string {
+	path := url
+	if u, err := urlpkg.Parse(url); err == nil {
+		path = u.Path
+	}
+	return apiPrefix.ReplaceAllString(path, "/api/")
+}
@@ -0,0 +1,96 @@
+package util
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/buger/jsonparser"
+)
+
+// 错误分类，配合 errors.Is 判断 APIError、TransportError 的类型
+var (
+	ErrNotLoggedIn  = errors.New("netease: 需要登录")    // 301
+	ErrRiskControl  = errors.New("netease: 触发风控")    // 8821, -462
+	ErrRateLimited  = errors.New("netease: 操作频繁")    // 405
+	ErrQRExpired    = errors.New("netease: 二维码已失效")  // 800
+	ErrQRWaiting    = errors.New("netease: 等待扫码")    // 801
+	ErrQRScanned    = errors.New("netease: 已扫码，待确认") // 802
+	ErrQRAuthorized = errors.New("netease: 授权登录成功")  // 803
+	ErrTransport    = errors.New("netease: 网络请求失败")
+)
+
+var codeErrors = map[float64]error{
+	301:  ErrNotLoggedIn,
+	405:  ErrRateLimited,
+	800:  ErrQRExpired,
+	801:  ErrQRWaiting,
+	802:  ErrQRScanned,
+	803:  ErrQRAuthorized,
+	8821: ErrRiskControl,
+	-462: ErrRiskControl,
+}
+
+// APIError 网易云音乐接口返回了非200的业务状态码
+type APIError struct {
+	Code       float64 // 业务状态码
+	Message    string  // 响应中的 message 或 msg
+	Endpoint   string  // 请求的接口地址
+	StatusCode int     // HTTP 状态码
+}
+
+// NewAPIError 根据响应体构造 APIError，响应中没有 code 字段时使用 HTTP 状态码
+func NewAPIError(endpoint string, statusCode int, body []byte) *APIError {
+	e := &APIError{
+		Code:       float64(statusCode),
+		Endpoint:   endpoint,
+		StatusCode: statusCode,
+	}
+	if code, err := jsonparser.GetFloat(body, "code"); err == nil {
+		e.Code = code
+	}
+	e.Message, _ = jsonparser.GetString(body, "message")
+	if e.Message == "" {
+		e.Message, _ = jsonparser.GetString(body, "msg")
+	}
+	return e
+}
+
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("netease: %s returned code %v", e.Endpoint, e.Code)
+	if e.StatusCode != 0 && e.StatusCode != 200 {
+		msg += fmt.Sprintf(" (http %d)", e.StatusCode)
+	}
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	return msg
+}
+
+// Is 使 errors.Is(err, ErrNotLoggedIn) 等分类判断生效
+func (e *APIError) Is(target error) bool {
+	return codeErrors[e.Code] == target
+}
+
+// TransportError 请求未能得到网易云音乐的响应，如网络错误、超时、被 UNM 拦截等
+type TransportError struct {
+	Endpoint string
+	Err      error
+}
+
+func (e *TransportError) Error() string {
+	return fmt.Sprintf("netease: request %s failed: %v", e.Endpoint, e.Err)
+}
+
+func (e *TransportError) Unwrap() error {
+	return e.Err
+}
+
+// Is 使 errors.Is(err, ErrTransport) 生效，同时仍可通过 Unwrap 判断 context.Canceled 等底层错误
+func (e *TransportError) Is(target error) bool {
+	return target == ErrTransport
+}
+
+// CodeError 将状态码转换为对应的分类错误，200 或未知状态码返回 nil
+func CodeError(code float64) error {
+	return codeErrors[code]
+}
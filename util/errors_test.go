@@ -0,0 +1,88 @@
+package util
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestAPIError_Is(t *testing.T) {
+	cases := []struct {
+		body   string
+		target error
+	}{
+		{`{"code":301,"msg":"需要登录"}`, ErrNotLoggedIn},
+		{`{"code":405,"message":"操作频繁，请稍候再试"}`, ErrRateLimited},
+		{`{"code":8821,"message":"需要行为验证码验证"}`, ErrRiskControl},
+		{`{"code":-462,"message":"绑定手机号"}`, ErrRiskControl},
+		{`{"code":800}`, ErrQRExpired},
+		{`{"code":801}`, ErrQRWaiting},
+		{`{"code":802}`, ErrQRScanned},
+		{`{"code":803}`, ErrQRAuthorized},
+	}
+	for _, c := range cases {
+		var err error = NewAPIError("/api/test", 200, []byte(c.body))
+		if !errors.Is(err, c.target) {
+			t.Errorf("%s: expected %v", c.body, c.target)
+		}
+		if errors.Is(err, ErrTransport) {
+			t.Errorf("%s: api error classified as transport error", c.body)
+		}
+	}
+
+	apiErr := NewAPIError("/api/test", 200, []byte(`{"code":301,"msg":"需要登录"}`))
+	if apiErr.Code != 301 || apiErr.Message != "需要登录" || apiErr.Endpoint != "/api/test" {
+		t.Errorf("unexpected api error: %+v", apiErr)
+	}
+}
+
+func TestTransportError_Is(t *testing.T) {
+	var err error = &TransportError{Endpoint: "/api/test", Err: context.Canceled}
+	if !errors.Is(err, ErrTransport) || !errors.Is(err, context.Canceled) {
+		t.Fatalf("unexpected classification: %v", err)
+	}
+}
+
+func TestCreateRequestContext_APIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"code":301,"msg":"需要登录"}`))
+	}))
+	defer server.Close()
+
+	code, _, _, err := NewClient(nil).CreateRequestContext(context.Background(), "POST", server.URL+"/api/nuser/account/get", map[string]string{}, &Options{Crypto: "weapi"})
+	var apiErr *APIError
+	if code != 301 || !errors.As(err, &apiErr) || !errors.Is(err, ErrNotLoggedIn) {
+		t.Fatalf("unexpected result: code %f, err %v", code, err)
+	}
+	if apiErr.StatusCode != http.StatusOK {
+		t.Errorf("unexpected status code: %d", apiErr.StatusCode)
+	}
+}
+
+func TestCreateRequestContext_NoCode(t *testing.T) {
+	var status atomic.Int32
+	status.Store(http.StatusOK)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(int(status.Load()))
+		_, _ = w.Write([]byte(`<html>bad gateway</html>`))
+	}))
+	defer server.Close()
+
+	client := NewClient(nil)
+	request := func() (float64, error) {
+		code, _, _, err := client.CreateRequestContext(context.Background(), "POST", server.URL+"/api/v3/song/detail", map[string]string{}, &Options{Crypto: "weapi"})
+		return code, err
+	}
+	// 没有 code 字段时以 HTTP 状态码为准
+	if code, err := request(); code != 200 || err != nil {
+		t.Fatalf("status 200: code %f, err %v", code, err)
+	}
+	status.Store(http.StatusBadGateway)
+	var apiErr *APIError
+	if code, err := request(); code != 502 || !errors.As(err, &apiErr) || apiErr.StatusCode != 502 {
+		t.Fatalf("status 502: code %f, err %v", code, err)
+	}
+}
@@ -0,0 +1,129 @@
+package util
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	urlpkg "net/url"
+)
+
+// Call 一次请求在加密前的明文信息
+type Call struct {
+	Method string
+	// URL 请求地址，此时尚未按加密方式改写为 /weapi/、/eapi/ 等
+	URL string
+	// Crypto 加密方式：weapi、eapi、linuxapi，为空时不加密
+	Crypto string
+	// Params 加密前的请求参数
+	Params map[string]interface{}
+	// Cookies 随请求发送的 Cookie，CookieJar 中的 Cookie 会在发送时另行附加
+	Cookies []*http.Cookie
+	// Header 额外的请求头，如链路追踪使用的请求头
+	Header http.Header
+	// Options CreateRequest 传入的选项，CallWeapi 发出的请求为 nil
+	Options *Options
+}
+
+// clone 复制 Call，使重试时每次请求都从原始参数开始
+func (call *Call) clone() *Call {
+	c := *call
+	c.Params = make(map[string]interface{}, len(call.Params))
+	for k, v := range call.Params {
+		c.Params[k] = v
+	}
+	c.Cookies = append([]*http.Cookie(nil), call.Cookies...)
+	c.Header = call.Header.Clone()
+	if c.Header == nil {
+		c.Header = make(http.Header)
+	}
+	if call.Options != nil {
+		options := *call.Options
+		c.Options = &options
+	}
+	return &c
+}
+
+// Reply 解压后的响应
+type Reply struct {
+	StatusCode int
+	Body       []byte
+	// Cookies 响应 Set-Cookie 设置的 Cookie
+	Cookies []*http.Cookie
+
+	// jarCookies 请求后 CookieJar 中该地址的全部 Cookie，作为 CreateRequest 返回的 resCookies
+	jarCookies []*http.Cookie
+}
+
+// Invoker 发送 Call 并返回响应，网络错误等导致没有响应时返回 error
+type Invoker func(ctx context.Context, call *Call) (*Reply, error)
+
+// Interceptor 请求拦截器
+//
+// 拦截器在加密前拿到明文的 Call，可以修改后交给 next 继续处理，也可以不调用 next 直接返回响应；
+// next 返回的 Reply 已经过 zlib 解压，业务状态码由 CreateRequest、CallWeapi 在拦截器之后解析。
+// 设置了重试策略时，每次重试都会重新经过拦截器
+type Interceptor func(ctx context.Context, call *Call, next Invoker) (*Reply, error)
+
+// invoke 依次经过 Client.Interceptors、Cache、Cassette 与 RateLimiter 后由 send 发送请求
+func (c *Client) invoke(ctx context.Context, call *Call, send Invoker) (*Reply, error) {
+	h := func(ctx context.Context, call *Call) (*Reply, error) {
+		if err := c.RateLimiter.Wait(ctx, call.URL); err != nil {
+			return nil, &TransportError{Endpoint: call.URL, Err: err}
+		}
+		return send(ctx, call)
+	}
+	if c.Cassette != nil {
+		h = chain(c.cassetteInterceptor, h)
+	}
+	if c.Cache != nil {
+		h = chain(c.cacheInterceptor, h)
+	}
+	for i := len(c.Interceptors) - 1; i >= 0; i-- {
+		h = chain(c.Interceptors[i], h)
+	}
+	return h(ctx, call)
+}
+
+func chain(interceptor Interceptor, next Invoker) Invoker {
+	return func(ctx context.Context, call *Call) (*Reply, error) {
+		return interceptor(ctx, call, next)
+	}
+}
+
+// cassetteInterceptor 录制模式下记录响应，回放模式下直接返回录制的响应，
+// 并像真实请求一样将响应设置的 Cookie 保存到 CookieJar
+func (c *Client) cassetteInterceptor(ctx context.Context, call *Call, next Invoker) (*Reply, error) {
+	cas := c.Cassette
+	if cas.replaying() {
+		in, err := cas.match(call.Method, call.URL, call.Params)
+		if err != nil {
+			return nil, &TransportError{Endpoint: call.URL, Err: err}
+		}
+		reply := &Reply{StatusCode: in.Status, Body: []byte(in.Body), Cookies: in.cookies()}
+		if u, err := urlpkg.Parse(call.URL); err == nil && len(reply.Cookies) > 0 {
+			c.CookieJar().SetCookies(u, reply.Cookies)
+		}
+		return reply, nil
+	}
+
+	reply, err := next(ctx, call)
+	if err == nil && cas.recording() {
+		cas.record(call.Method, call.URL, call.Crypto, call.Params, reply.StatusCode, reply.Body, reply.Cookies)
+	}
+	return reply, err
+}
+
+// formParams 将 Call.Params 转换为表单参数，非字符串的值按 JSON 编码
+func formParams(params map[string]interface{}) map[string]string {
+	data := make(map[string]string, len(params))
+	for k, v := range params {
+		switch v := v.(type) {
+		case string:
+			data[k] = v
+		default:
+			b, _ := json.Marshal(v)
+			data[k] = string(b)
+		}
+	}
+	return data
+}
@@ -0,0 +1,95 @@
+package util
+
+import (
+	"bytes"
+	"compress/zlib"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestClient_Interceptors(t *testing.T) {
+	var (
+		order    []string
+		observed []byte
+		sent     map[string]interface{}
+	)
+	client := &Client{
+		Interceptors: []Interceptor{
+			func(ctx context.Context, call *Call, next Invoker) (*Reply, error) {
+				order = append(order, "outer")
+				if call.Crypto != "eapi" || call.Params["id"] != "405998841" {
+					t.Errorf("unexpected plaintext call: %+v", call)
+				}
+				call.Header.Set("X-Trace-Id", "trace-1")
+				call.Cookies = append(call.Cookies, &http.Cookie{Name: "custom", Value: "1"})
+				reply, err := next(ctx, call)
+				if err == nil {
+					observed = reply.Body
+				}
+				return reply, err
+			},
+			func(ctx context.Context, call *Call, next Invoker) (*Reply, error) {
+				order = append(order, "inner")
+				call.Params["limit"] = 30
+				return next(ctx, call)
+			},
+		},
+		Transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			if r.Header.Get("X-Trace-Id") != "trace-1" {
+				t.Errorf("header not applied: %v", r.Header)
+			}
+			if c, err := r.Cookie("custom"); err != nil || c.Value != "1" {
+				t.Errorf("cookie not applied: %v", r.Header.Get("Cookie"))
+			}
+			_ = r.ParseForm()
+			var err error
+			if _, sent, err = EapiDecrypt(r.PostForm.Get("params")); err != nil {
+				t.Errorf("decrypt: %v", err)
+			}
+			// 响应经过 zlib 压缩
+			var buf bytes.Buffer
+			w := zlib.NewWriter(&buf)
+			_, _ = w.Write([]byte(`{"code":200,"songs":[]}`))
+			_ = w.Close()
+			return &http.Response{StatusCode: 200, Header: http.Header{}, Body: io.NopCloser(&buf), Request: r}, nil
+		}),
+	}
+
+	code, body, _, err := client.CreateRequestContext(context.Background(), "POST", "https://music.163.com/api/v3/song/detail", map[string]string{"id": "405998841"}, &Options{Crypto: "eapi", Url: "/api/v3/song/detail"})
+	if err != nil || code != 200 || string(body) != `{"code":200,"songs":[]}` {
+		t.Fatalf("code %f, body %s, err %v", code, body, err)
+	}
+	if strings.Join(order, ",") != "outer,inner" {
+		t.Errorf("unexpected order: %v", order)
+	}
+	if string(observed) != `{"code":200,"songs":[]}` {
+		t.Errorf("interceptor did not see the inflated body: %q", observed)
+	}
+	if sent["limit"] != "30" || sent["id"] != "405998841" {
+		t.Errorf("mutated params not sent: %v", sent)
+	}
+}
+
+func TestClient_InterceptorShortCircuit(t *testing.T) {
+	client := &Client{
+		Interceptors: []Interceptor{func(ctx context.Context, call *Call, next Invoker) (*Reply, error) {
+			return &Reply{StatusCode: 200, Body: []byte(`{"code":301}`)}, nil
+		}},
+		Transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			t.Errorf("unexpected request: %s", r.URL)
+			return nil, io.EOF
+		}),
+	}
+
+	code, _, _, err := client.CreateRequestContext(context.Background(), "POST", "https://music.163.com/api/nuser/account/get", map[string]string{}, &Options{Crypto: "weapi"})
+	if code != 301 || err == nil {
+		t.Fatalf("code %f, err %v", code, err)
+	}
+	code, body, err := client.CallWeapi("https://music.163.com/weapi/song/enhance/player/url/v1", map[string]interface{}{})
+	if code != 301 || string(body) != `{"code":301}` || err != nil {
+		t.Fatalf("code %f, body %s, err %v", code, body, err)
+	}
+}
@@ -0,0 +1,164 @@
+package util
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"os"
+	"strings"
+	"sync/atomic"
+	"unicode/utf8"
+)
+
+// RedactedValue 日志中替换敏感信息使用的值
+const RedactedValue = "[REDACTED]"
+
+var (
+	logLevel = new(slog.LevelVar)
+	logger   atomic.Pointer[slog.Logger]
+)
+
+func init() {
+	logger.Store(slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: logLevel})))
+}
+
+// SetLogger 设置未指定 Client.Logger 时使用的日志记录器，l 为 nil 时不输出日志
+func SetLogger(l *slog.Logger) {
+	if l == nil {
+		l = slog.New(discardHandler{})
+	}
+	logger.Store(l)
+}
+
+// Logger 返回未指定 Client.Logger 时使用的日志记录器
+func Logger() *slog.Logger {
+	return logger.Load()
+}
+
+// SetLogLevel 设置默认日志记录器的级别，默认为 slog.LevelInfo
+//
+// 请求失败记录为 Error，业务状态码不为200记录为 Warn，其余请求记录为 Debug。
+// 通过 SetLogger 设置的日志记录器由其 Handler 自行控制级别
+func SetLogLevel(level slog.Level) {
+	logLevel.Set(level)
+}
+
+// logger 返回 Client 使用的日志记录器
+func (c *Client) logger() *slog.Logger {
+	if c.Logger != nil {
+		return c.Logger
+	}
+	return Logger()
+}
+
+// logRequest 记录一次请求的结果，其中的凭证 Cookie 与登录参数会被脱敏
+func (c *Client) logRequest(ctx context.Context, method, url string, params map[string]interface{}, code float64, body []byte, cookies []*http.Cookie, err error) {
+	l := c.logger()
+	level := slog.LevelDebug
+	switch {
+	case errors.Is(err, ErrTransport):
+		level = slog.LevelError
+	case code == 801 || code == 802 || code == 803:
+		// 扫码登录轮询中的状态，不是错误
+	case code != 200:
+		level = slog.LevelWarn
+	}
+	if !l.Enabled(ctx, level) {
+		return
+	}
+	attrs := []slog.Attr{
+		slog.String("method", method),
+		slog.String("url", url),
+		slog.Any("params", redactedParams(params)),
+		slog.Float64("code", code),
+	}
+	if code != 200 {
+		attrs = append(attrs, slog.String("body", truncate(redactBody(url, body), 512)))
+	}
+	if len(cookies) > 0 {
+		attrs = append(attrs, slog.Any("cookies", redactedCookies(cookies)))
+	}
+	if err != nil {
+		attrs = append(attrs, slog.String("error", err.Error()))
+	}
+	l.LogAttrs(ctx, level, "netease request", attrs...)
+}
+
+// sensitiveCookies 日志中需要脱敏的 Cookie
+var sensitiveCookies = map[string]bool{
+	"MUSIC_U":   true,
+	"MUSIC_A":   true,
+	"MUSIC_R_T": true,
+	"MUSIC_A_T": true,
+	"__csrf":    true,
+	"NMTID":     true,
+}
+
+// sensitiveParams 日志中需要脱敏的请求参数，与登录、验证码相关
+var sensitiveParams = map[string]bool{
+	"phone":       true,
+	"cellphone":   true,
+	"email":       true,
+	"username":    true,
+	"password":    true,
+	"md5password": true,
+	"captcha":     true,
+	"csrf_token":  true,
+	"checkToken":  true,
+	"token":       true,
+	"MUSIC_U":     true,
+	"MUSIC_A":     true,
+}
+
+type redactedParams map[string]interface{}
+
+func (p redactedParams) LogValue() slog.Value {
+	attrs := make([]slog.Attr, 0, len(p))
+	for k, v := range p {
+		if sensitiveParams[k] {
+			v = RedactedValue
+		}
+		attrs = append(attrs, slog.Any(k, v))
+	}
+	return slog.GroupValue(attrs...)
+}
+
+type redactedCookies []*http.Cookie
+
+func (cs redactedCookies) LogValue() slog.Value {
+	attrs := make([]slog.Attr, 0, len(cs))
+	for _, c := range cs {
+		value := c.Value
+		if sensitiveCookies[c.Name] {
+			value = RedactedValue
+		}
+		attrs = append(attrs, slog.String(c.Name, value))
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// redactBody 登录相关接口的响应中可能包含 token 等凭证，不记录其内容
+func redactBody(url string, body []byte) string {
+	if strings.Contains(url, "login") || strings.Contains(url, "register") {
+		return RedactedValue
+	}
+	return string(body)
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n] + "..."
+}
+
+type discardHandler struct{}
+
+func (discardHandler) Enabled(context.Context, slog.Level) bool  { return false }
+func (discardHandler) Handle(context.Context, slog.Record) error { return nil }
+func (h discardHandler) WithAttrs([]slog.Attr) slog.Handler      { return h }
+func (h discardHandler) WithGroup(string) slog.Handler           { return h }
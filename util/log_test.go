@@ -0,0 +1,70 @@
+package util
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestClient_LogRedaction(t *testing.T) {
+	var buf bytes.Buffer
+	client := &Client{
+		Logger: slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelInfo})),
+		Transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			resp := &http.Response{
+				StatusCode: 200,
+				Header:     http.Header{"Set-Cookie": {"MUSIC_U=secret-music-u; Path=/", "os=pc; Path=/"}},
+				Body:       http.NoBody,
+				Request:    r,
+			}
+			if strings.HasSuffix(r.URL.Path, "/search/hot") {
+				resp.Body = newBody(`{"code":200}`)
+			} else {
+				resp.Body = newBody(`{"code":8821,"message":"需要行为验证码验证"}`)
+			}
+			return resp, nil
+		}),
+	}
+
+	_, _, _, err := client.CreateRequestContext(context.Background(), "POST", "https://music.163.com/api/sms/captcha/sent", map[string]string{"cellphone": "13800000000", "ctcode": "86"}, &Options{Crypto: "weapi"})
+	if err == nil {
+		t.Fatal("expected api error")
+	}
+	_, _, _ = client.CallWeapi("https://music.163.com/weapi/w/login/cellphone", map[string]interface{}{"phone": "13800000000", "password": "e10adc3949ba59abbe56e057f20f883e"})
+
+	out := buf.String()
+	for _, secret := range []string{"13800000000", "secret-music-u", "e10adc3949ba59abbe56e057f20f883e"} {
+		if strings.Contains(out, secret) {
+			t.Errorf("log leaks %q: %s", secret, out)
+		}
+	}
+	if !strings.Contains(out, RedactedValue) || !strings.Contains(out, `"ctcode":"86"`) || !strings.Contains(out, `"level":"WARN"`) {
+		t.Errorf("unexpected log output: %s", out)
+	}
+
+	// 成功的请求记录为 Debug，默认不输出
+	buf.Reset()
+	if _, _, _, err := client.CreateRequestContext(context.Background(), "POST", "https://music.163.com/api/search/hot", map[string]string{}, &Options{Crypto: "weapi"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("unexpected log output: %s", buf.String())
+	}
+}
+
+func TestAddCookiesToJar_Errors(t *testing.T) {
+	if err := AddCookiesToJar(nil, map[string]string{"a": "b"}, "https://music.163.com"); err == nil {
+		t.Error("expected error for nil jar")
+	}
+	if err := AddCookiesToJar(NewClient(nil).CookieJar(), map[string]string{"a": "b"}, "://bad"); err == nil {
+		t.Error("expected error for malformed url")
+	}
+}
+
+func newBody(s string) io.ReadCloser {
+	return io.NopCloser(strings.NewReader(s))
+}
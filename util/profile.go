@@ -0,0 +1,162 @@
+package util
+
+import (
+	"context"
+	"net/http"
+)
+
+// ClientProfile 模拟的客户端，决定请求附带的 os、appver 等设备信息以及 eapi 请求的 User-Agent
+//
+// weapi 请求模拟的是网页，User-Agent 仍由 Options.Ua 决定；linuxapi 请求固定使用 LinuxProfile 的 User-Agent
+type ClientProfile struct {
+	OS          string
+	AppVer      string
+	OSVer       string
+	VersionCode string
+	MobileName  string
+	Resolution  string
+	Channel     string
+	// UserAgent eapi 请求使用的 User-Agent，为空时与 weapi 相同
+	UserAgent string
+}
+
+// PCProfile 返回 Windows 桌面客户端
+func PCProfile() *ClientProfile {
+	return &ClientProfile{
+		OS:          "pc",
+		AppVer:      "3.0.18.203152",
+		OSVer:       "Microsoft-Windows-10-Professional-build-22631-64bit",
+		VersionCode: "140",
+		Resolution:  "1920x1080",
+		Channel:     "netease",
+		UserAgent:   "Mozilla/5.0 (Windows NT 10.0; WOW64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/91.0.4472.164 NeteaseMusicDesktop/3.0.18.203152",
+	}
+}
+
+// IOSProfile 返回 iPhone 客户端
+func IOSProfile() *ClientProfile {
+	return &ClientProfile{
+		OS:          "ios",
+		AppVer:      iosAppVersion,
+		OSVer:       "17.4.1",
+		VersionCode: "140",
+		MobileName:  "iPhone15,2",
+		Resolution:  "1179x2556",
+		Channel:     "distribution",
+		UserAgent:   "NeteaseMusic " + iosAppVersion + "/5038 (iPhone; iOS 17.4.1; zh_CN)",
+	}
+}
+
+// LegacyPCProfile 返回 2.7.1 版 Windows 桌面客户端，红心接口（LikeService）一直使用该版本
+func LegacyPCProfile() *ClientProfile {
+	p := PCProfile()
+	p.AppVer = "2.7.1.198277"
+	p.UserAgent = "Mozilla/5.0 (Windows NT 10.0; WOW64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/91.0.4472.164 NeteaseMusicDesktop/2.7.1.198277"
+	return p
+}
+
+// LegacyIOSProfile 返回 8.7.01 版 iPhone 客户端，邮箱登录（LoginEmailService）一直使用该版本
+func LegacyIOSProfile() *ClientProfile {
+	p := IOSProfile()
+	p.AppVer = "8.7.01"
+	p.UserAgent = "NeteaseMusic 8.7.01/5038 (iPhone; iOS 17.4.1; zh_CN)"
+	return p
+}
+
+// AndroidProfile 返回 Android 客户端
+func AndroidProfile() *ClientProfile {
+	return &ClientProfile{
+		OS:          "android",
+		AppVer:      "8.20.20.231215173437",
+		OSVer:       "14",
+		VersionCode: "8020020",
+		MobileName:  "23013RK75C",
+		Resolution:  "1440x3200",
+		Channel:     "xiaomi",
+		UserAgent:   "NeteaseMusic/8.20.20.231215173437(8020020);Dalvik/2.1.0 (Linux; U; Android 14; 23013RK75C Build/UKQ1.230804.001)",
+	}
+}
+
+// WebProfile 返回网页版，不附带客户端版本号
+func WebProfile() *ClientProfile {
+	return &ClientProfile{
+		OS:          "pc",
+		VersionCode: "140",
+		Resolution:  "1920x1080",
+		UserAgent:   chooseUserAgent("pc"),
+	}
+}
+
+// LinuxProfile 返回 Linux 桌面客户端，linuxapi 请求总是使用它的 User-Agent
+func LinuxProfile() *ClientProfile {
+	return &ClientProfile{
+		OS:          "linux",
+		AppVer:      "1.2.1.0428",
+		OSVer:       "Deepin 20.9",
+		VersionCode: "140",
+		Resolution:  "1920x1080",
+		Channel:     "netease",
+		UserAgent:   "Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/60.0.3112.90 Safari/537.36",
+	}
+}
+
+// ProfileByName 按名称返回预置的 ClientProfile：pc、ios、android、web、linux，名称未知时返回 nil
+func ProfileByName(name string) *ClientProfile {
+	switch name {
+	case "pc":
+		return PCProfile()
+	case "ios":
+		return IOSProfile()
+	case "android":
+		return AndroidProfile()
+	case "web":
+		return WebProfile()
+	case "linux":
+		return LinuxProfile()
+	}
+	return nil
+}
+
+type profileKey struct{}
+
+// WithProfile 使 ctx 发出的请求模拟 profile 对应的客户端，覆盖 Client.Profile 与 service 默认的客户端
+func WithProfile(ctx context.Context, profile *ClientProfile) context.Context {
+	return context.WithValue(ctx, profileKey{}, profile)
+}
+
+// selectedProfile 返回调用方通过 WithProfile 或 Client.Profile 选择的客户端，都没有时返回 nil
+func (c *Client) selectedProfile(ctx context.Context) *ClientProfile {
+	if p, ok := ctx.Value(profileKey{}).(*ClientProfile); ok && p != nil {
+		return p
+	}
+	// ImportSession 可能同时替换 Profile
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.Profile
+}
+
+// profileFor 返回请求模拟的客户端，依次为 WithProfile、Client.Profile、Options.Profile，
+// 都没有时按 Options.Cookies 中的 os、appver 等 Cookie 组装，默认为 iOS
+func (c *Client) profileFor(ctx context.Context, options *Options) *ClientProfile {
+	if p := c.selectedProfile(ctx); p != nil {
+		return p
+	}
+	if options.Profile != nil {
+		return options.Profile
+	}
+	return cookieProfile(options.Cookies)
+}
+
+// cookieProfile 兼容直接通过 Cookie 指定 os、appver 等设备信息的调用方
+func cookieProfile(cookies []*http.Cookie) *ClientProfile {
+	os := CookieValueByName(cookies, "os", "ios")
+	return &ClientProfile{
+		OS:          os,
+		AppVer:      CookieValueByName(cookies, "appver", Ternary(os != "pc", iosAppVersion, "")),
+		OSVer:       CookieValueByName(cookies, "osver", "17.4.1"),
+		VersionCode: CookieValueByName(cookies, "versioncode", "140"),
+		MobileName:  CookieValueByName(cookies, "mobilename", ""),
+		Resolution:  CookieValueByName(cookies, "resolution", "1920x1080"),
+		Channel:     CookieValueByName(cookies, "channel", ""),
+	}
+}
@@ -0,0 +1,96 @@
+package util
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+// profileTransport 记录请求的 User-Agent、os Cookie 以及 eapi 加密参数中的 header
+type profileTransport struct {
+	ua, os string
+	header map[string]interface{}
+}
+
+func (p *profileTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	p.ua, p.os, p.header = r.Header.Get("User-Agent"), "", nil
+	if c, err := r.Cookie("os"); err == nil {
+		p.os = c.Value
+	}
+	_ = r.ParseForm()
+	if params := r.PostForm.Get("params"); params != "" {
+		if _, data, err := EapiDecrypt(params); err == nil {
+			p.header, _ = data["header"].(map[string]interface{})
+		}
+	}
+	return &http.Response{StatusCode: 200, Header: http.Header{}, Body: newBody(`{"code":200}`), Request: r}, nil
+}
+
+func TestClient_Profile(t *testing.T) {
+	rt := &profileTransport{}
+	client := &Client{Transport: rt}
+	eapi := func(ctx context.Context, options *Options) {
+		options.Crypto, options.Url = "eapi", "/api/song/enhance/player/url/v1"
+		if code, _, _, err := client.CreateRequestContext(ctx, "POST", "https://interface.music.163.com/eapi/song/enhance/player/url/v1", map[string]string{}, options); err != nil || code != 200 {
+			t.Fatalf("code %f, err %v", code, err)
+		}
+	}
+
+	// 未选择时兼容 Cookie 中的 os、appver
+	eapi(context.Background(), &Options{Cookies: []*http.Cookie{{Name: "os", Value: "pc"}, {Name: "osver", Value: "10"}}})
+	if rt.os != "pc" || rt.header["appver"] != "" || rt.header["osver"] != "10" || rt.ua != chooseUserAgent("pc") {
+		t.Fatalf("cookie profile not applied: ua %q, os %q, header %v", rt.ua, rt.os, rt.header)
+	}
+
+	// service 默认的客户端
+	eapi(context.Background(), &Options{Profile: PCProfile()})
+	if pc := PCProfile(); rt.os != "pc" || rt.header["appver"] != pc.AppVer || rt.header["osver"] != pc.OSVer || rt.ua != pc.UserAgent {
+		t.Fatalf("options profile not applied: ua %q, os %q, header %v", rt.ua, rt.os, rt.header)
+	}
+
+	// Client.Profile 覆盖 service 默认的客户端
+	client.Profile = AndroidProfile()
+	eapi(context.Background(), &Options{Profile: PCProfile()})
+	if android := AndroidProfile(); rt.os != "android" || rt.header["appver"] != android.AppVer || rt.header["mobilename"] != android.MobileName || rt.header["channel"] != android.Channel || rt.ua != android.UserAgent {
+		t.Fatalf("client profile not applied: ua %q, os %q, header %v", rt.ua, rt.os, rt.header)
+	}
+
+	// WithProfile 覆盖 Client.Profile
+	eapi(WithProfile(context.Background(), IOSProfile()), &Options{})
+	if ios := IOSProfile(); rt.os != "ios" || rt.header["appver"] != ios.AppVer || rt.ua != ios.UserAgent {
+		t.Fatalf("request profile not applied: ua %q, os %q, header %v", rt.ua, rt.os, rt.header)
+	}
+
+	// weapi 模拟网页，User-Agent 不变
+	if _, _, _, err := client.CreateRequestContext(context.Background(), "POST", "https://music.163.com/weapi/cloudsearch/pc", map[string]string{}, &Options{Crypto: "weapi"}); err != nil {
+		t.Fatal(err)
+	}
+	if rt.os != "android" || rt.ua != chooseUserAgent("pc") {
+		t.Fatalf("weapi request: ua %q, os %q", rt.ua, rt.os)
+	}
+	if _, _, err := client.CallWeapi("https://music.163.com/weapi/song/enhance/player/url/v1", map[string]interface{}{}); err != nil {
+		t.Fatal(err)
+	}
+	if rt.os != "android" {
+		t.Fatalf("CallWeapi request: os %q", rt.os)
+	}
+
+	// linuxapi 总是使用 Linux 的 User-Agent
+	if _, _, _, err := client.CreateRequestContext(context.Background(), "POST", "https://music.163.com/api/song/lyric", map[string]string{}, &Options{Crypto: "linuxapi"}); err != nil {
+		t.Fatal(err)
+	}
+	if rt.ua != LinuxProfile().UserAgent {
+		t.Fatalf("linuxapi request: ua %q", rt.ua)
+	}
+}
+
+func TestProfileByName(t *testing.T) {
+	for _, name := range []string{"pc", "ios", "android", "web", "linux"} {
+		if p := ProfileByName(name); p == nil || p.OS == "" {
+			t.Errorf("profile %s: %+v", name, p)
+		}
+	}
+	if ProfileByName("symbian") != nil {
+		t.Error("unknown profile returned")
+	}
+}
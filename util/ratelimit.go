@@ -0,0 +1,130 @@
+package util
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RateLimiter 客户端限流器，按令牌桶算法限制请求频率
+//
+// 全局限制作用于所有请求，按接口前缀设置的限制额外作用于匹配的请求，
+// 同一请求匹配多个前缀时以最长的为准。
+// 前缀中的 /weapi/、/eapi/ 等会被统一为 /api/，因此 /weapi/v3/song/detail 同样限制 eapi 的调用。
+// 同一个 RateLimiter 可以被多个 Client 共享，例如多个账号使用同一出口 IP 时。
+type RateLimiter struct {
+	mu       sync.Mutex
+	global   *tokenBucket
+	prefixes []string
+	buckets  map[string]*tokenBucket
+}
+
+// NewRateLimiter 创建全局每秒最多 rate 次、突发 burst 次的限流器，rate 小于等于0时全局不限制
+func NewRateLimiter(rate float64, burst int) *RateLimiter {
+	l := &RateLimiter{buckets: make(map[string]*tokenBucket)}
+	if rate > 0 {
+		l.global = newTokenBucket(rate, burst)
+	}
+	return l
+}
+
+// SetLimit 限制路径以 prefix 开头的接口每秒最多 rate 次、突发 burst 次，rate 小于等于0时取消该限制
+func (l *RateLimiter) SetLimit(prefix string, rate float64, burst int) {
+	prefix = apiPath(prefix)
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, ok := l.buckets[prefix]; ok {
+		for i, p := range l.prefixes {
+			if p == prefix {
+				l.prefixes = append(l.prefixes[:i], l.prefixes[i+1:]...)
+				break
+			}
+		}
+		delete(l.buckets, prefix)
+	}
+	if rate <= 0 {
+		return
+	}
+	l.buckets[prefix] = newTokenBucket(rate, burst)
+	l.prefixes = append(l.prefixes, prefix)
+	// 长的前缀优先匹配
+	sort.Slice(l.prefixes, func(i, j int) bool { return len(l.prefixes[i]) > len(l.prefixes[j]) })
+}
+
+// Wait 等待直到允许请求 url，ctx 被取消时返回 ctx.Err()
+//
+// l 为 nil 时不限制
+func (l *RateLimiter) Wait(ctx context.Context, url string) error {
+	if l == nil {
+		return nil
+	}
+	path := apiPath(url)
+	l.mu.Lock()
+	global := l.global
+	var bucket *tokenBucket
+	for _, prefix := range l.prefixes {
+		if strings.HasPrefix(path, prefix) {
+			bucket = l.buckets[prefix]
+			break
+		}
+	}
+	l.mu.Unlock()
+
+	if bucket != nil {
+		if err := bucket.wait(ctx); err != nil {
+			return err
+		}
+	}
+	if global != nil {
+		return global.wait(ctx)
+	}
+	return nil
+}
+
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64 // 每秒补充的令牌数
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(rate float64, burst int) *tokenBucket {
+	if burst < 1 {
+		burst = 1
+	}
+	return &tokenBucket{rate: rate, burst: float64(burst), tokens: float64(burst), last: time.Now()}
+}
+
+// wait 取走一个令牌，令牌不足时等待补充
+func (b *tokenBucket) wait(ctx context.Context) error {
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		b.mu.Lock()
+		now := time.Now()
+		b.tokens += now.Sub(b.last).Seconds() * b.rate
+		if b.tokens > b.burst {
+			b.tokens = b.burst
+		}
+		b.last = now
+		if b.tokens >= 1 {
+			b.tokens--
+			b.mu.Unlock()
+			return nil
+		}
+		delay := time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+		b.mu.Unlock()
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package util
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRateLimiter_Prefix(t *testing.T) {
+	l := NewRateLimiter(0, 0)
+	l.SetLimit("/weapi/v3/song/detail", 20, 1)
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if err := l.Wait(context.Background(), "https://music.163.com/eapi/v3/song/detail"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	// 突发1次，之后每 50ms 一次
+	if elapsed := time.Since(start); elapsed < 90*time.Millisecond {
+		t.Fatalf("limit not applied, elapsed %s", elapsed)
+	}
+
+	// 其他接口不受前缀限制影响
+	start = time.Now()
+	for i := 0; i < 10; i++ {
+		_ = l.Wait(context.Background(), "https://music.163.com/weapi/cloudsearch/pc")
+	}
+	if elapsed := time.Since(start); elapsed > 20*time.Millisecond {
+		t.Fatalf("unrelated endpoint limited, elapsed %s", elapsed)
+	}
+
+	// 取消限制
+	l.SetLimit("/api/v3/song/detail", 0, 0)
+	start = time.Now()
+	for i := 0; i < 10; i++ {
+		_ = l.Wait(context.Background(), "https://music.163.com/weapi/v3/song/detail")
+	}
+	if elapsed := time.Since(start); elapsed > 20*time.Millisecond {
+		t.Fatalf("limit not removed, elapsed %s", elapsed)
+	}
+}
+
+func TestRateLimiter_Global(t *testing.T) {
+	l := NewRateLimiter(1, 2)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	for i := 0; i < 2; i++ {
+		if err := l.Wait(ctx, "/api/v3/song/detail"); err != nil {
+			t.Fatalf("burst request %d: %v", i, err)
+		}
+	}
+	if err := l.Wait(ctx, "/api/cloudsearch/pc"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+}
+
+func TestClient_RateLimiter(t *testing.T) {
+	var calls int32
+	l := NewRateLimiter(0, 0)
+	l.SetLimit("/api/v3/song/detail", 0.001, 1)
+	client := &Client{RateLimiter: l, Transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		atomic.AddInt32(&calls, 1)
+		return &http.Response{StatusCode: 200, Header: http.Header{}, Body: newBody(`{"code":200}`), Request: r}, nil
+	})}
+
+	if code, _, _, err := client.CreateRequestContext(context.Background(), "POST", "https://music.163.com/weapi/v3/song/detail", map[string]string{}, &Options{Crypto: "weapi"}); err != nil || code != 200 {
+		t.Fatalf("code %f, err %v", code, err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	code, _, err := client.CallWeapiContext(ctx, "https://music.163.com/weapi/v3/song/detail", map[string]interface{}{})
+	if !errors.Is(err, context.DeadlineExceeded) || !errors.Is(err, ErrTransport) || code != 0 {
+		t.Fatalf("expected limiter wait to honour ctx, got code %f, err %v", code, err)
+	}
+	if atomic.LoadInt32(&calls) != 1 {
+		t.Fatalf("unexpected calls: %d", calls)
+	}
+}
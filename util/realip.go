@@ -0,0 +1,41 @@
+package util
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"strconv"
+)
+
+// RealIPRandom 作为 Client.RealIP 或 WithRealIP 的值时，每次请求随机选择一个中国大陆 IP
+const RealIPRandom = "random"
+
+// chinaIPPrefixes 随机选择 IP 使用的中国大陆网段，与 NeteaseCloudMusicApi 的 randomCNIP 一致
+var chinaIPPrefixes = []string{"116.25", "116.76", "116.77", "116.78"}
+
+// RandomChinaIP 随机返回一个中国大陆 IP
+func RandomChinaIP() string {
+	return chinaIPPrefixes[rand.Intn(len(chinaIPPrefixes))] + "." + strconv.Itoa(rand.Intn(256)) + "." + strconv.Itoa(1+rand.Intn(254))
+}
+
+type realIPKey struct{}
+
+// WithRealIP 使 ctx 发出的请求使用 ip 作为 X-Real-IP，覆盖 Client.RealIP，ip 为空时不发送
+func WithRealIP(ctx context.Context, ip string) context.Context {
+	return context.WithValue(ctx, realIPKey{}, ip)
+}
+
+// realIPHeader 返回请求需要附带的 X-Real-IP、X-Forwarded-For
+func (c *Client) realIPHeader(ctx context.Context) http.Header {
+	ip := c.RealIP
+	if v, ok := ctx.Value(realIPKey{}).(string); ok {
+		ip = v
+	}
+	if ip == "" {
+		return nil
+	}
+	if ip == RealIPRandom {
+		ip = RandomChinaIP()
+	}
+	return http.Header{"X-Real-Ip": {ip}, "X-Forwarded-For": {ip}}
+}
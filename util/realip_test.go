@@ -0,0 +1,65 @@
+package util
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestRandomChinaIP(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		ip := RandomChinaIP()
+		if net.ParseIP(ip) == nil {
+			t.Fatalf("invalid ip: %s", ip)
+		}
+		var ok bool
+		for _, prefix := range chinaIPPrefixes {
+			ok = ok || strings.HasPrefix(ip, prefix+".")
+		}
+		if !ok {
+			t.Fatalf("ip outside mainland ranges: %s", ip)
+		}
+	}
+}
+
+func TestClient_RealIP(t *testing.T) {
+	var realIP, forwardedFor string
+	client := &Client{RealIP: "116.25.1.1", Transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		realIP, forwardedFor = r.Header.Get("X-Real-IP"), r.Header.Get("X-Forwarded-For")
+		return &http.Response{StatusCode: 200, Header: http.Header{}, Body: newBody(`{"code":200}`), Request: r}, nil
+	})}
+	request := func(ctx context.Context) {
+		if code, _, _, err := client.CreateRequestContext(ctx, "POST", "https://music.163.com/api/cloudsearch/pc", map[string]string{}, &Options{Crypto: "eapi", Url: "/api/cloudsearch/pc"}); err != nil || code != 200 {
+			t.Fatalf("code %f, err %v", code, err)
+		}
+	}
+
+	request(context.Background())
+	if realIP != "116.25.1.1" || forwardedFor != "116.25.1.1" {
+		t.Fatalf("client ip not sent: %q, %q", realIP, forwardedFor)
+	}
+
+	request(WithRealIP(context.Background(), "116.76.2.2"))
+	if realIP != "116.76.2.2" || forwardedFor != "116.76.2.2" {
+		t.Fatalf("per-call ip not sent: %q, %q", realIP, forwardedFor)
+	}
+
+	request(WithRealIP(context.Background(), RealIPRandom))
+	if !strings.HasPrefix(realIP, "116.") || realIP != forwardedFor {
+		t.Fatalf("random ip not sent: %q, %q", realIP, forwardedFor)
+	}
+
+	request(WithRealIP(context.Background(), ""))
+	if realIP != "" || forwardedFor != "" {
+		t.Fatalf("ip sent after disabling: %q, %q", realIP, forwardedFor)
+	}
+
+	if _, _, err := client.CallWeapi("https://music.163.com/weapi/song/enhance/player/url/v1", map[string]interface{}{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if realIP != "116.25.1.1" || forwardedFor != "116.25.1.1" {
+		t.Fatalf("ip not sent by CallWeapi: %q, %q", realIP, forwardedFor)
+	}
+}
@@ -3,14 +3,13 @@ package util
 import (
 	"bytes"
 	"compress/zlib"
-	"encoding/hex"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
-	"log"
 	"math/rand"
 	"net/http"
-	"net/http/cookiejar"
 	urlpkg "net/url"
 	"regexp"
 	"strconv"
@@ -27,11 +26,6 @@ const (
 	iosAppVersion = "9.0.65"
 )
 
-var (
-	globalDeviceId string
-	deviceIdOnce   sync.Once
-)
-
 type Options struct {
 	Crypto  string
 	Ua      string
@@ -39,6 +33,8 @@ type Options struct {
 	Token   string
 	Url     string
 	SkipUNM bool
+	// Profile service 默认模拟的客户端，为 nil 时按 Cookies 中的 os、appver 等组装
+	Profile *ClientProfile
 }
 
 func chooseUserAgent(ua string) string {
@@ -52,33 +48,14 @@ func chooseUserAgent(ua string) string {
 	return userAgentList["pc"]
 }
 
-var (
-	globalCookieJar http.CookieJar
-	once            sync.Once
-)
-
+// SetGlobalCookieJar 设置默认 Client 使用的 CookieJar
 func SetGlobalCookieJar(jar http.CookieJar) {
-	globalCookieJar = jar
+	defaultClient.SetCookieJar(jar)
 }
 
+// GetGlobalCookieJar 返回默认 Client 使用的 CookieJar
 func GetGlobalCookieJar() http.CookieJar {
-	once.Do(func() {
-		if globalCookieJar == nil {
-			// 为空时才新建一个jar对象
-			jar, _ := cookiejar.New(nil)
-			globalCookieJar = jar
-		}
-		if CheckSDeviceId(globalCookieJar) == "" {
-			// jar中没有sDeviceId则生成一个并添加
-			sDeviceId := GenerateSDeviceId()
-			cookieMap := map[string]string{
-				"sDeviceId": sDeviceId,
-			}
-			host := "https://music.163.com"
-			AddCookiesToJar(globalCookieJar, cookieMap, host)
-		}
-	})
-	return globalCookieJar
+	return defaultClient.CookieJar()
 }
 
 func CookieValueByName(cookies []*http.Cookie, name string, fallback string) string {
@@ -95,53 +72,125 @@ func CookieValueByName(cookies []*http.Cookie, name string, fallback string) str
 	return cookie.Value
 }
 
+// CreateRequest 使用默认 Client 发送请求
 func CreateRequest(method, url string, data map[string]string, options *Options) (resCode float64, resResp []byte, resCookies []*http.Cookie) {
+	return defaultClient.CreateRequest(method, url, data, options)
+}
+
+// CreateRequestContext 使用默认 Client 发送请求，ctx 的超时与取消会作用于底层的 HTTP 请求
+func CreateRequestContext(ctx context.Context, method, url string, data map[string]string, options *Options) (resCode float64, resResp []byte, resCookies []*http.Cookie, err error) {
+	return defaultClient.CreateRequestContext(ctx, method, url, data, options)
+}
+
+// CreateRequest 发送请求，c 为 nil 时使用默认 Client
+func (c *Client) CreateRequest(method, url string, data map[string]string, options *Options) (resCode float64, resResp []byte, resCookies []*http.Cookie) {
+	resCode, resResp, resCookies, _ = c.CreateRequestContext(context.Background(), method, url, data, options)
+	return
+}
+
+// CreateRequestContext 发送请求，ctx 的超时与取消会作用于底层的 HTTP 请求
+//
+// 请求失败时 resCode 为 520，resResp 为错误信息，err 为 *TransportError；
+// 业务状态码不为200时 err 为 *APIError。响应中没有 code 字段时 resCode 为 HTTP 状态码（旧版本固定为200）
+//
+// 请求依次经过 Client.Interceptors、Cache、Cassette、RateLimiter 后发出，
+// 设置了 Client.Retry 时按重试策略重新发送失败的请求
+func (c *Client) CreateRequestContext(ctx context.Context, method, url string, data map[string]string, options *Options) (resCode float64, resResp []byte, resCookies []*http.Cookie, err error) {
+	c = c.orDefault()
+	call := &Call{
+		Method:  method,
+		URL:     url,
+		Crypto:  options.Crypto,
+		Params:  stringParams(data),
+		Cookies: options.Cookies,
+		Header:  c.realIPHeader(ctx),
+		Options: options,
+	}
+	err = c.withRetry(ctx, url, func() error {
+		var attemptErr error
+		resCode, resResp, resCookies, attemptErr = c.attemptRequest(ctx, call.clone())
+		return attemptErr
+	})
+	return
+}
+
+// attemptRequest 经过拦截器发送一次请求，并解析响应中的业务状态码
+func (c *Client) attemptRequest(ctx context.Context, call *Call) (resCode float64, resResp []byte, resCookies []*http.Cookie, err error) {
 	defer func() {
-		if resCode != 200 {
-			log.Printf("url: %s, method: %s, reqData: %#v, reqOptions: %+v, resCode: %f, resResp: %s, resCookies: %#v", url, method, data, options, resCode, resResp, resCookies)
-		}
+		c.logRequest(ctx, call.Method, call.URL, call.Params, resCode, resResp, resCookies, err)
 	}()
 
-	deviceIdOnce.Do(func() {
-		idx := rand.Intn(len(deviceIds) - 1)
-		globalDeviceId = deviceIds[idx]
-	})
+	if err = ctx.Err(); err != nil {
+		return 520, []byte(err.Error()), nil, &TransportError{Endpoint: call.URL, Err: err}
+	}
+	reply, err := c.invoke(ctx, call, c.sendRequest)
+	if err != nil {
+		return 520, []byte(transportMessage(err)), nil, err
+	}
+	resCode, err = responseCode(call.URL, reply.StatusCode, reply.Body)
+	resCookies = reply.jarCookies
+	if resCookies == nil {
+		// 来自缓存、回放或拦截器的响应
+		resCookies = reply.Cookies
+	}
+	return resCode, reply.Body, resCookies, err
+}
 
-	globalCookieJar = GetGlobalCookieJar()
+// sendRequest 按 Call 的加密方式加密参数并发送请求，是 CreateRequest 拦截器链的最后一环
+func (c *Client) sendRequest(ctx context.Context, call *Call) (*Reply, error) {
+	var (
+		method  = call.Method
+		url     = call.URL
+		data    = formParams(call.Params)
+		options = call.Options
+	)
+	options.Crypto, options.Cookies = call.Crypto, call.Cookies
+
+	cookieJar := c.CookieJar()
 
 	if u, err := urlpkg.Parse(url); err == nil {
-		options.Cookies = append(options.Cookies, globalCookieJar.Cookies(u)...)
+		options.Cookies = append(options.Cookies, cookieJar.Cookies(u)...)
 	}
 	req := requests.Requests()
+	bindContext(req, ctx)
+	httpClient, err := c.httpClient(c.proxyFor(ctx))
+	if err != nil {
+		return nil, &TransportError{Endpoint: url, Err: err}
+	}
+	jar := newRequestJar(cookieJar, url)
+	httpClient.Jar = jar
+	req.Client = httpClient
 
 	var (
-		os          = CookieValueByName(options.Cookies, "os", "ios")
-		appver      = CookieValueByName(options.Cookies, "appver", Ternary(os != "pc", iosAppVersion, ""))
-		osver       = CookieValueByName(options.Cookies, "osver", "17.4.1")
-		deviceId    = CookieValueByName(options.Cookies, "deviceId", globalDeviceId)
-		versionCode = CookieValueByName(options.Cookies, "versioncode", "140")
-		mobileName  = CookieValueByName(options.Cookies, "mobilename", "")
+		profile     = c.profileFor(ctx, options)
+		os          = profile.OS
+		appver      = profile.AppVer
+		osver       = profile.OSVer
+		device      = c.Device()
+		deviceId    = CookieValueByName(options.Cookies, "deviceId", device.DeviceId)
+		versionCode = profile.VersionCode
+		mobileName  = profile.MobileName
 		buildver    = CookieValueByName(options.Cookies, "buildver", strconv.FormatInt(time.Now().Unix(), 10))
-		resolution  = CookieValueByName(options.Cookies, "resolution", "1920x1080")
-		channel     = CookieValueByName(options.Cookies, "channel", "")
+		resolution  = profile.Resolution
+		channel     = profile.Channel
 		musicU      = CookieValueByName(options.Cookies, "MUSIC_U", "")
 		musicA      = CookieValueByName(options.Cookies, "MUSIC_A", "")
 		csrfToken   = CookieValueByName(options.Cookies, "__csrf", "")
 	)
 
-	req.Client.Jar = globalCookieJar
 	req.Header.Set("User-Agent", chooseUserAgent(options.Ua))
 	req.Header.Set("os", os)
 	req.Header.Set("appver", appver)
 
-	options.Cookies = append(options.Cookies,
-		&http.Cookie{Name: "__remember_me", Value: "true"},
-		&http.Cookie{Name: "os", Value: os},
-		&http.Cookie{Name: "appver", Value: appver},
-	)
-	if musicU != "" {
-		options.Cookies = append(options.Cookies, &http.Cookie{Name: "_ntes_nuid", Value: hex.EncodeToString([]byte(RandStringRunes(16)))})
+	extraCookies := []*http.Cookie{
+		{Name: "__remember_me", Value: "true"},
+		{Name: "os", Value: os},
+		{Name: "appver", Value: appver},
+		{Name: "sDeviceId", Value: device.SDeviceId},
+		{Name: "_ntes_nuid", Value: device.NtesNUID},
+		{Name: "NMTID", Value: device.NMTID},
 	}
+	options.Cookies = append(options.Cookies, extraCookies...)
 
 	if method == "POST" {
 		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
@@ -149,17 +198,23 @@ func CreateRequest(method, url string, data map[string]string, options *Options)
 	if strings.Contains(url, "music.163.com") {
 		req.Header.Set("Referer", "https://music.163.com")
 	}
-	for _, cookie := range options.Cookies {
-		req.SetCookie(cookie)
-	}
-
-	if strings.Contains(url, "login") {
-		options.Cookies = append(options.Cookies, &http.Cookie{Name: "NMTID", Value: hex.EncodeToString([]byte(RandStringRunes(16)))})
+	for key, values := range call.Header {
+		req.Header.Del(key)
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
 	}
+	// CookieJar 中的 Cookie 由 jar 附带，这里只设置调用方传入以及上面追加的 Cookie
+	jar.set(call.Cookies...)
+	jar.set(extraCookies...)
 
 	if options.Crypto == "weapi" {
 		data["csrf_token"] = csrfToken
-		data = Weapi(data)
+		pub, err := c.weapiPublicKey()
+		if err != nil {
+			return nil, err
+		}
+		data = weapiWithKey(data, pub)
 		reg, _ := regexp.Compile(`/\w*api/`)
 		url = reg.ReplaceAllString(url, "/weapi/")
 	} else if options.Crypto == "linuxapi" {
@@ -169,7 +224,7 @@ func CreateRequest(method, url string, data map[string]string, options *Options)
 		linuxApiData["url"] = reg.ReplaceAllString(url, "/api/")
 		linuxApiData["params"] = data
 		data = Linuxapi(linuxApiData)
-		req.Header.Set("User-Agent", "Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/60.0.3112.90 Safari/537.36")
+		req.Header.Set("User-Agent", LinuxProfile().UserAgent)
 		url = "https://music.163.com/api/linux/forward"
 	} else if options.Crypto == "eapi" {
 		eapiData := make(map[string]interface{})
@@ -199,7 +254,10 @@ func CreateRequest(method, url string, data map[string]string, options *Options)
 		}
 
 		for key, value := range header {
-			req.SetCookie(&http.Cookie{Name: key, Value: value, Path: "/"})
+			jar.set(&http.Cookie{Name: key, Value: value})
+		}
+		if profile.UserAgent != "" && options.Ua == "" {
+			req.Header.Set("User-Agent", profile.UserAgent)
 		}
 		eapiData["header"] = header
 		data = Eapi(options.Url, eapiData)
@@ -208,9 +266,9 @@ func CreateRequest(method, url string, data map[string]string, options *Options)
 	}
 
 	var (
-		err     error
 		resp    *requests.Response
-		UNMFlag = UNMSwitch && !options.SkipUNM
+		unm     = c.unmConfig()
+		UNMFlag = unm.Enable && !options.SkipUNM
 	)
 	if method == "POST" {
 		var form requests.Datas = data
@@ -219,18 +277,17 @@ func CreateRequest(method, url string, data map[string]string, options *Options)
 		resp, err = req.Get(url, requests.DryRun(UNMFlag))
 	}
 	if err != nil {
-		resCode, resResp, resCookies = 520, []byte(err.Error()), nil
-		return
+		return nil, &TransportError{Endpoint: url, Err: err}
 	}
 
 	if UNMFlag {
-		ConfigInit()
+		release := acquireUNM(unm)
+		defer release()
 
 		request := req.HttpRequest()
 		netease := processor.RequestBefore(request)
 		if netease == nil {
-			resCode, resResp, resCookies = 520, []byte("Request Blocked:"+url), nil
-			return
+			return nil, &TransportError{Endpoint: url, Err: errors.New("Request Blocked:" + url)}
 		}
 
 		if method == "POST" {
@@ -240,8 +297,7 @@ func CreateRequest(method, url string, data map[string]string, options *Options)
 			resp, err = req.Get(url)
 		}
 		if err != nil {
-			resCode, resResp, resCookies = 520, []byte("Request Error:"+url), nil
-			return
+			return nil, &TransportError{Endpoint: url, Err: err}
 		}
 		response := resp.R
 		defer response.Body.Close()
@@ -250,24 +306,116 @@ func CreateRequest(method, url string, data map[string]string, options *Options)
 		resp.ReloadContent()
 	}
 
-	resCookies = resp.Cookies()
-
-	resResp = resp.Content()
+	resResp := resp.Content()
 	// fmt.Println(string(body))
 	b := bytes.NewReader(resResp)
 	var out bytes.Buffer
-	r, err := zlib.NewReader(b)
 	// 数据被压缩 进行解码
-	if err == nil {
+	if r, zerr := zlib.NewReader(b); zerr == nil {
 		_, _ = io.Copy(&out, r)
 		resResp = out.Bytes()
 	}
+	c.recordAccount(resp.R.Cookies(), resResp)
+	return &Reply{StatusCode: resp.R.StatusCode, Body: resResp, Cookies: resp.R.Cookies(), jarCookies: resp.Cookies()}, nil
+}
 
-	resCode, err = jsonparser.GetFloat(resResp, "code")
-	if err != nil {
-		resCode = 200
+// responseCode 解析响应中的业务状态码，不为200时返回 *APIError
+func responseCode(url string, statusCode int, body []byte) (float64, error) {
+	var code float64
+	if c, err := jsonparser.GetFloat(body, "code"); err == nil {
+		code = c
+	} else {
+		// 没有 code 字段时以 HTTP 状态码为准
+		code = float64(statusCode)
 	}
-	return
+	if code != 200 {
+		apiErr := NewAPIError(url, statusCode, body)
+		apiErr.Code = code
+		return code, apiErr
+	}
+	return code, nil
+}
+
+// transportMessage 返回请求失败时作为 resResp 的错误信息
+func transportMessage(err error) string {
+	var transportErr *TransportError
+	if errors.As(err, &transportErr) && transportErr.Err != nil {
+		return transportErr.Err.Error()
+	}
+	return err.Error()
+}
+
+// bindContext 将 ctx 绑定到 requests 内部复用的 *http.Request 上，使之后的 Get/Post 都受 ctx 控制
+func bindContext(req *requests.Request, ctx context.Context) {
+	r := req.HttpRequest()
+	*r = *r.WithContext(ctx)
+}
+
+// requestJar 单次请求使用的 CookieJar
+//
+// 通过 set 设置的 Cookie 只随本次请求发送，同名时覆盖 Client 的 CookieJar 中的 Cookie；
+// 响应下发的 Cookie 写入 Client 的 CookieJar。requests 的 SetCookie 会把 Cookie 按请求路径写入 CookieJar，
+// 导致 MUSIC_U 等 Cookie 在各接口路径下留有副本，ExportSession、ClearSession 都无法处理，因此不再使用
+type requestJar struct {
+	http.CookieJar
+	host string
+
+	mu      sync.Mutex
+	cookies []*http.Cookie
+}
+
+// newRequestJar 返回发往 rawURL 的请求使用的 requestJar，set 的 Cookie 只发往 rawURL 的域名与网易云音乐的域名
+func newRequestJar(jar http.CookieJar, rawURL string) *requestJar {
+	j := &requestJar{CookieJar: jar}
+	if u, err := urlpkg.Parse(rawURL); err == nil {
+		j.host = u.Hostname()
+	}
+	return j
+}
+
+// set 设置随本次请求发送的 Cookie，同名的 Cookie 以最后一次设置的为准
+func (j *requestJar) set(cookies ...*http.Cookie) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	for _, cookie := range cookies {
+		cookie := &http.Cookie{Name: cookie.Name, Value: cookie.Value}
+		replaced := false
+		for i, c := range j.cookies {
+			if c.Name == cookie.Name {
+				j.cookies[i], replaced = cookie, true
+				break
+			}
+		}
+		if !replaced {
+			j.cookies = append(j.cookies, cookie)
+		}
+	}
+}
+
+func (j *requestJar) Cookies(u *urlpkg.URL) []*http.Cookie {
+	stored := j.CookieJar.Cookies(u)
+	host := u.Hostname()
+	if host != j.host && host != HostMusic && !strings.HasSuffix(host, "."+HostMusic) {
+		return stored
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	cookies := append(make([]*http.Cookie, 0, len(j.cookies)+len(stored)), j.cookies...)
+	for _, cookie := range stored {
+		if !hasCookie(j.cookies, cookie.Name) {
+			cookies = append(cookies, cookie)
+		}
+	}
+	return cookies
+}
+
+func hasCookie(cookies []*http.Cookie, name string) bool {
+	for _, c := range cookies {
+		if c.Name == name {
+			return true
+		}
+	}
+	return false
 }
 
 // -------------------分割线 -------------------
@@ -287,13 +435,29 @@ type request struct {
 	Datas   map[string]string
 	Json    map[string]string
 	Proxy   string
+
+	jar *requestJar
+	err error
 }
 
 // 初始化并返回一个request结构体以进行发送请求前的准备
 func NewRequest(url string, proxy ...string) *request {
+	return defaultClient.NewRequest(url, proxy...)
+}
+
+// NewRequest 使用 Client 的 CookieJar 初始化request结构体，未传入代理地址时使用 Client 的代理
+func (c *Client) NewRequest(url string, proxy ...string) *request {
+	c = c.orDefault()
+	// 如果传入了代理地址，则设置代理
+	if len(proxy) > 0 && proxy[0] != "" {
+		return c.newRequest(url, proxy[0])
+	}
+	return c.newRequest(url, c.Proxy)
+}
+
+// newRequest 初始化使用 proxy 代理的request结构体，proxy 为空时不使用代理
+func (c *Client) newRequest(url string, proxy string) *request {
 	req := requests.Requests()
-	cookieJar := GetGlobalCookieJar()
-	req.Client.Jar = cookieJar
 	r := &request{
 		Req: req,
 		Url: url,
@@ -304,25 +468,43 @@ func NewRequest(url string, proxy ...string) *request {
 		Params: map[string]string{},
 		Datas:  map[string]string{},
 		Json:   map[string]string{},
+		Proxy:  proxy,
+		jar:    newRequestJar(c.CookieJar(), url),
 	}
-	// 如果传入了代理地址，则设置代理
-	if len(proxy) > 0 && proxy[0] != "" {
-		r.Proxy = proxy[0]
-		r.Req.Proxy(r.Proxy)
+	// 代理地址无效时在发送时返回错误
+	if httpClient, err := c.httpClient(r.Proxy); err != nil {
+		r.err = err
+	} else {
+		httpClient.Jar = r.jar
+		r.Req.Client = httpClient
 	}
 	return r
 }
 
+// SetCookie 设置只随本次请求发送的 Cookie，不会写入 CookieJar
+func (req *request) SetCookie(cookie *http.Cookie) {
+	req.jar.set(cookie)
+}
+
+// WithContext 使之后的 SendGet、SendPost 受 ctx 的超时与取消控制
+func (req *request) WithContext(ctx context.Context) *request {
+	bindContext(req.Req, ctx)
+	return req
+}
+
 // 发送 GET 请求
 //
 // 设置结构体中的Params字段以传入query参数
 func (req *request) SendGet() (Response *http.Response, err error) {
+	if req.err != nil {
+		return nil, fmt.Errorf("GET request error: %w, url: %s", req.err, req.Url)
+	}
 	resp, err := req.Req.Get(
 		req.Url,
 		requests.Header(req.Headers),
 		requests.Params(req.Params))
 	if err != nil {
-		return nil, fmt.Errorf("GET request error: %s, url: %s", err.Error(), req.Url)
+		return nil, fmt.Errorf("GET request error: %w, url: %s", err, req.Url)
 	}
 	return resp.R, nil
 }
@@ -332,6 +514,9 @@ func (req *request) SendGet() (Response *http.Response, err error) {
 // 若要发送Json数据，请设置结构体中的Json字段
 // 发送FormData数据则设置结构体中的Datas字段
 func (req *request) SendPost() (Response *http.Response, err error) {
+	if req.err != nil {
+		return nil, fmt.Errorf("POST request error: %w, url: %s", req.err, req.Url)
+	}
 	// 判断一下post的数据类型
 	if len(req.Json) > 0 {
 		resp, err := req.Req.PostJson(
@@ -340,7 +525,7 @@ func (req *request) SendPost() (Response *http.Response, err error) {
 			requests.Datas(req.Json),
 		)
 		if err != nil {
-			return nil, fmt.Errorf("POST request error: %s, url: %s", err.Error(), req.Url)
+			return nil, fmt.Errorf("POST request error: %w, url: %s", err, req.Url)
 		}
 		Response = resp.R
 	} else {
@@ -350,7 +535,7 @@ func (req *request) SendPost() (Response *http.Response, err error) {
 			requests.Datas(req.Datas),
 		)
 		if err != nil {
-			return nil, fmt.Errorf("POST request error: %s, url: %s", err.Error(), req.Url)
+			return nil, fmt.Errorf("POST request error: %w, url: %s", err, req.Url)
 		}
 		Response = resp.R
 	}
@@ -366,28 +551,104 @@ func (req *request) SendPost() (Response *http.Response, err error) {
 //   - bodyBytes: 完整的 API 响应体。
 //   - err: 如果在请求过程中发生任何错误，则返回非 nil 的 error。
 func CallWeapi(api string, data map[string]interface{}, proxy ...string) (code float64, bodyBytes []byte, err error) {
-	encodedParams, err := ApiParamsEncode(data)
+	return defaultClient.CallWeapi(api, data, proxy...)
+}
+
+// CallWeapiContext 同 CallWeapi，ctx 的超时与取消会作用于底层的 HTTP 请求
+func CallWeapiContext(ctx context.Context, api string, data map[string]interface{}, proxy ...string) (code float64, bodyBytes []byte, err error) {
+	return defaultClient.CallWeapiContext(ctx, api, data, proxy...)
+}
+
+// CallWeapi 使用 Client 调用网易云音乐的 web 端 API，c 为 nil 时使用默认 Client
+//
+// 与 CallWeapiContext 不同，业务状态码不为200时不返回 error，只通过 code 体现
+func (c *Client) CallWeapi(api string, data map[string]interface{}, proxy ...string) (code float64, bodyBytes []byte, err error) {
+	code, bodyBytes, err = c.CallWeapiContext(context.Background(), api, data, proxy...)
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		err = nil
+	}
+	return code, bodyBytes, err
+}
+
+// CallWeapiContext 同 Client.CallWeapi，ctx 的超时与取消会作用于底层的 HTTP 请求
+//
+// 请求失败时返回 *TransportError，业务状态码不为200时返回 *APIError
+//
+// 设置了 Client.Retry 时按重试策略重新发送失败的请求
+func (c *Client) CallWeapiContext(ctx context.Context, api string, data map[string]interface{}, proxy ...string) (code float64, bodyBytes []byte, err error) {
+	c = c.orDefault()
+	call := &Call{Method: "POST", URL: api, Crypto: "weapi", Params: data, Header: c.realIPHeader(ctx)}
+	err = c.withRetry(ctx, api, func() error {
+		var attemptErr error
+		code, bodyBytes, attemptErr = c.attemptWeapi(ctx, call.clone(), proxy...)
+		return attemptErr
+	})
+	return
+}
+
+// attemptWeapi 经过拦截器发送一次 CallWeapi 请求，并解析响应中的业务状态码
+func (c *Client) attemptWeapi(ctx context.Context, call *Call, proxy ...string) (code float64, bodyBytes []byte, err error) {
+	defer func() {
+		c.logRequest(ctx, call.Method, call.URL, call.Params, code, bodyBytes, nil, err)
+	}()
+	if err := ctx.Err(); err != nil {
+		return 0, nil, &TransportError{Endpoint: call.URL, Err: err}
+	}
+	reply, err := c.invoke(ctx, call, func(ctx context.Context, call *Call) (*Reply, error) {
+		return c.sendWeapi(ctx, call, proxy...)
+	})
+	if err != nil {
+		return 0, nil, err
+	}
+	return weapiResult(call.URL, reply.StatusCode, reply.Body)
+}
+
+// sendWeapi 加密参数并发送 CallWeapi 请求，是 CallWeapi 拦截器链的最后一环
+func (c *Client) sendWeapi(ctx context.Context, call *Call, proxy ...string) (*Reply, error) {
+	pub, err := c.weapiPublicKey()
+	if err != nil {
+		return nil, err
+	}
+	encodedParams, err := apiParamsEncodeWithKey(call.Params, pub)
 	if err != nil {
-		return 0, nil, fmt.Errorf("failed to encode api params: %w", err)
+		return nil, fmt.Errorf("failed to encode api params: %w", err)
 	}
-	req := NewRequest(api, proxy...)
+	req := c.newRequest(call.URL, c.proxyFor(ctx, proxy...)).WithContext(ctx)
 	req.Datas = encodedParams
+	for key := range call.Header {
+		req.Headers[key] = call.Header.Get(key)
+	}
+	if profile := c.selectedProfile(ctx); profile != nil {
+		req.SetCookie(&http.Cookie{Name: "os", Value: profile.OS})
+		req.SetCookie(&http.Cookie{Name: "appver", Value: profile.AppVer})
+	}
+	for _, cookie := range call.Cookies {
+		req.SetCookie(cookie)
+	}
 
 	resp, err := req.SendPost()
 	if err != nil {
-		return 0, nil, fmt.Errorf("failed to send request: %w", err)
+		return nil, &TransportError{Endpoint: call.URL, Err: err}
 	}
 	defer resp.Body.Close()
 
 	// 读取响应体
-	bodyBytes, err = io.ReadAll(resp.Body)
+	bodyBytes, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return 0, nil, fmt.Errorf("failed to read response body: %w", err)
+		return nil, &TransportError{Endpoint: call.URL, Err: fmt.Errorf("failed to read response body: %w", err)}
 	}
+	c.recordAccount(resp.Cookies(), bodyBytes)
+	return &Reply{StatusCode: resp.StatusCode, Body: bodyBytes, Cookies: resp.Cookies()}, nil
+}
 
-	// 解析并验证响应中的 'code'
+// weapiResult 解析并验证 CallWeapi 响应中的 'code'
+func weapiResult(api string, statusCode int, bodyBytes []byte) (code float64, _ []byte, err error) {
 	var respData map[string]interface{}
 	if err := json.Unmarshal(bodyBytes, &respData); err != nil {
+		if statusCode != http.StatusOK {
+			return float64(statusCode), bodyBytes, NewAPIError(api, statusCode, bodyBytes)
+		}
 		return 0, bodyBytes, fmt.Errorf("error unmarshaling response JSON: %w", err)
 	}
 
@@ -400,6 +661,9 @@ func CallWeapi(api string, data map[string]interface{}, proxy ...string) (code f
 	if !ok {
 		return 0, bodyBytes, fmt.Errorf("'code' field is not a number, got type: %T", codeValue)
 	}
+	if code != 200 {
+		return code, bodyBytes, NewAPIError(api, statusCode, bodyBytes)
+	}
 
 	return code, bodyBytes, nil
 }
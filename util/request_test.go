@@ -0,0 +1,74 @@
+package util
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCreateRequestContext_Deadline(t *testing.T) {
+	done := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-done:
+		}
+	}))
+	defer server.Close()
+	defer close(done)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	client := NewClient(nil)
+	start := time.Now()
+	code, _, _, err := client.CreateRequestContext(ctx, "POST", server.URL+"/api/hang", map[string]string{}, &Options{Crypto: "weapi"})
+	if !errors.Is(err, context.DeadlineExceeded) || !errors.Is(err, ErrTransport) {
+		t.Fatalf("expected deadline exceeded, got code %f, err %v", code, err)
+	}
+	if time.Since(start) > 5*time.Second {
+		t.Fatal("request was not cancelled by the deadline")
+	}
+}
+
+func TestCallWeapiContext_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, _, err := NewClient(nil).CallWeapiContext(ctx, "https://music.163.com/weapi/song/enhance/player/url/v1", map[string]interface{}{}); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context canceled, got %v", err)
+	}
+}
+
+func TestClient_InvalidWeapiPublicKey(t *testing.T) {
+	sent := false
+	transport := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		sent = true
+		return &http.Response{StatusCode: 200, Header: http.Header{}, Body: newBody(`{"code":200}`), Request: r}, nil
+	})
+	ecKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	der, _ := x509.MarshalPKIXPublicKey(&ecKey.PublicKey)
+	for name, key := range map[string][]byte{
+		"not pem": []byte("not a key"),
+		"not rsa": pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}),
+	} {
+		client := &Client{Transport: transport, WeapiPublicKey: key}
+		if _, _, _, err := client.CreateRequestContext(context.Background(), "POST", "https://music.163.com/weapi/song/detail", map[string]string{}, &Options{Crypto: "weapi"}); err == nil || !strings.Contains(err.Error(), "WeapiPublicKey") {
+			t.Errorf("%s: CreateRequest err = %v", name, err)
+		}
+		if _, _, err := client.CallWeapiContext(context.Background(), "https://music.163.com/weapi/song/detail", map[string]interface{}{}); err == nil || !strings.Contains(err.Error(), "WeapiPublicKey") {
+			t.Errorf("%s: CallWeapi err = %v", name, err)
+		}
+	}
+	if sent {
+		t.Error("request sent with an unusable public key")
+	}
+}
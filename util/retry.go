@@ -0,0 +1,133 @@
+package util
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	urlpkg "net/url"
+	"os"
+	"syscall"
+	"time"
+)
+
+// RetryPolicy 请求失败后的重试策略
+//
+// 默认只重试网络错误、405 操作频繁以及 HTTP 429、5xx。
+// IsWriteAPI 判断为写操作的接口不会因网络错误重试，以免重复提交，
+// 但服务端明确拒绝（操作频繁）的请求仍会重试。
+type RetryPolicy struct {
+	// MaxAttempts 最多请求的次数（包括首次请求），小于等于1时不重试
+	MaxAttempts int
+	// BaseDelay 第一次重试前的等待时间，之后每次翻倍，默认 200ms
+	BaseDelay time.Duration
+	// MaxDelay 单次等待的上限，默认 5s
+	MaxDelay time.Duration
+	// RetryWrites 为 true 时写操作也按 Retryable 重试
+	RetryWrites bool
+	// Retryable 判断错误是否可以重试，为 nil 时使用 Retryable
+	Retryable func(err error) bool
+}
+
+// DefaultRetryPolicy 返回最多请求3次的重试策略
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   200 * time.Millisecond,
+		MaxDelay:    5 * time.Second,
+	}
+}
+
+// Retryable 判断 CreateRequest、CallWeapi 返回的错误是否值得重试
+//
+// 只有连接重置、超时等网络错误、405 操作频繁以及 HTTP 429、5xx 返回 true；
+// 调用方取消或超时、代理配置错误、证书校验失败、需要登录、触发风控等重试无法恢复的错误返回 false
+func Retryable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if errors.Is(err, ErrRateLimited) {
+		return true
+	}
+	if errors.Is(err, ErrTransport) {
+		return isNetworkError(err)
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode == http.StatusTooManyRequests || apiErr.StatusCode >= 500
+	}
+	return false
+}
+
+// isNetworkError 判断是否是连接重置、连接意外关闭、超时等网络层面的错误
+func isNetworkError(err error) bool {
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, syscall.ECONNRESET) || errors.Is(err, os.ErrDeadlineExceeded) {
+		return true
+	}
+	for ; err != nil; err = errors.Unwrap(err) {
+		// http.Client 返回的所有错误都包装在 *url.Error 中，它本身也实现了 net.Error
+		if _, ok := err.(*urlpkg.Error); ok {
+			continue
+		}
+		if _, ok := err.(net.Error); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *RetryPolicy) shouldRetry(url string, err error) bool {
+	if IsWriteAPI(url) && !p.RetryWrites {
+		// 写操作只在服务端明确拒绝时重试
+		return errors.Is(err, ErrRateLimited)
+	}
+	if p.Retryable != nil {
+		return p.Retryable(err)
+	}
+	return Retryable(err)
+}
+
+// backoff 返回第 n 次重试前的等待时间，在指数退避的基础上加入随机抖动
+func (p *RetryPolicy) backoff(n int) time.Duration {
+	base, maxDelay := p.BaseDelay, p.MaxDelay
+	if base <= 0 {
+		base = 200 * time.Millisecond
+	}
+	if maxDelay <= 0 {
+		maxDelay = 5 * time.Second
+	}
+	delay := base
+	for i := 1; i < n && delay < maxDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxDelay {
+		delay = maxDelay
+	}
+	// 在 [delay/2, delay] 之间随机，避免多个请求同时重试
+	return delay/2 + rand.N(delay/2+1)
+}
+
+// withRetry 按 Client 的重试策略执行 attempt，直到成功、不可重试或达到次数上限
+func (c *Client) withRetry(ctx context.Context, url string, attempt func() error) error {
+	err := attempt()
+	p := c.Retry
+	if p == nil {
+		return err
+	}
+	for n := 1; n < p.MaxAttempts && err != nil && p.shouldRetry(url, err); n++ {
+		timer := time.NewTimer(p.backoff(n))
+		select {
+		case <-ctx.Done():
+			// 与请求前 ctx 已结束的情况一致，返回包裹 ctx.Err() 的 TransportError，并附上最后一次的错误
+			timer.Stop()
+			return &TransportError{Endpoint: url, Err: fmt.Errorf("%w (last error: %v)", ctx.Err(), err)}
+		case <-timer.C:
+		}
+		c.logger().DebugContext(ctx, "netease request retry", "url", url, "attempt", n+1, "error", err.Error())
+		err = attempt()
+	}
+	return err
+}
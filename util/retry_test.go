@@ -0,0 +1,187 @@
+package util
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// flakyTransport 依次返回 responses 中的结果，空字符串表示网络错误
+func flakyTransport(calls *int32, responses ...string) http.RoundTripper {
+	return roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		n := int(atomic.AddInt32(calls, 1)) - 1
+		if n >= len(responses) {
+			n = len(responses) - 1
+		}
+		if responses[n] == "" {
+			return nil, &net.OpError{Op: "read", Net: "tcp", Err: os.NewSyscallError("read", syscall.ECONNRESET)}
+		}
+		status := http.StatusOK
+		if responses[n] == "503" {
+			status = http.StatusServiceUnavailable
+		}
+		return &http.Response{StatusCode: status, Header: http.Header{}, Body: newBody(responses[n]), Request: r}, nil
+	})
+}
+
+func TestClient_Retry(t *testing.T) {
+	policy := &RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond}
+	cases := []struct {
+		name      string
+		url       string
+		responses []string
+		wantCode  float64
+		wantCalls int32
+	}{
+		{"transport error", "https://music.163.com/api/v3/song/detail", []string{"", "", `{"code":200}`}, 200, 3},
+		{"rate limited", "https://music.163.com/api/v3/song/detail", []string{`{"code":405}`, `{"code":200}`}, 200, 2},
+		{"gives up", "https://music.163.com/api/v3/song/detail", []string{""}, 520, 3},
+		{"not logged in", "https://music.163.com/api/v3/song/detail", []string{`{"code":301}`, `{"code":200}`}, 301, 1},
+		{"risk control", "https://music.163.com/api/v3/song/detail", []string{`{"code":8821}`, `{"code":200}`}, 8821, 1},
+		{"write not duplicated", "https://music.163.com/api/playlist/manipulate/tracks", []string{"", `{"code":200}`}, 520, 1},
+		{"write rejected", "https://music.163.com/weapi/resource/comments/add", []string{`{"code":405}`, `{"code":200}`}, 200, 2},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var calls int32
+			client := &Client{Retry: policy, Transport: flakyTransport(&calls, c.responses...)}
+			code, _, _, _ := client.CreateRequestContext(context.Background(), "POST", c.url, map[string]string{"id": "1"}, &Options{Crypto: "weapi"})
+			if code != c.wantCode || calls != c.wantCalls {
+				t.Fatalf("code %f, calls %d; want code %f, calls %d", code, calls, c.wantCode, c.wantCalls)
+			}
+		})
+	}
+}
+
+func TestClient_RetryCallWeapi(t *testing.T) {
+	var calls int32
+	client := &Client{
+		Retry:     &RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond},
+		Transport: flakyTransport(&calls, "503", `{"code":200}`),
+	}
+	code, _, err := client.CallWeapi("https://music.163.com/weapi/song/enhance/player/url/v1", map[string]interface{}{"ids": "[1]"})
+	if err != nil || code != 200 || calls != 2 {
+		t.Fatalf("code %f, calls %d, err %v", code, calls, err)
+	}
+}
+
+func TestClient_RetryHonoursContext(t *testing.T) {
+	var calls int32
+	client := &Client{
+		Retry:     &RetryPolicy{MaxAttempts: 5, BaseDelay: time.Hour, MaxDelay: time.Hour},
+		Transport: flakyTransport(&calls, ""),
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, _, _, err := client.CreateRequestContext(ctx, "POST", "https://music.163.com/api/v3/song/detail", map[string]string{}, &Options{Crypto: "weapi"})
+	if !errors.Is(err, ErrTransport) || !errors.Is(err, context.DeadlineExceeded) || calls != 1 || time.Since(start) > time.Second {
+		t.Fatalf("calls %d, elapsed %s, err %v", calls, time.Since(start), err)
+	}
+}
+
+func TestClient_RetryCanceledDuringBackoff(t *testing.T) {
+	var calls int32
+	client := &Client{
+		Retry:     &RetryPolicy{MaxAttempts: 5, BaseDelay: time.Hour, MaxDelay: time.Hour},
+		Transport: flakyTransport(&calls, `{"code":405}`),
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(20*time.Millisecond, cancel)
+	_, _, err := client.CallWeapiContext(ctx, "https://music.163.com/weapi/song/enhance/player/url/v1", map[string]interface{}{})
+	if !errors.Is(err, context.Canceled) || calls != 1 {
+		t.Fatalf("calls %d, err %v", calls, err)
+	}
+	if !strings.Contains(err.Error(), "405") {
+		t.Errorf("last error not reported: %v", err)
+	}
+}
+
+func TestRetryable(t *testing.T) {
+	transport := func(err error) error {
+		return &TransportError{Endpoint: "https://music.163.com/api/v3/song/detail", Err: err}
+	}
+	_, proxyErr := url.Parse("http://[::1")
+	cases := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"connection reset", transport(&url.Error{Op: "Post", URL: "https://music.163.com", Err: &net.OpError{Op: "read", Net: "tcp", Err: os.NewSyscallError("read", syscall.ECONNRESET)}}), true},
+		{"connection refused", transport(&url.Error{Op: "Post", URL: "https://music.163.com", Err: &net.OpError{Op: "dial", Net: "tcp", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)}}), true},
+		{"eof", transport(&url.Error{Op: "Post", URL: "https://music.163.com", Err: io.EOF}), true},
+		{"timeout", transport(&url.Error{Op: "Post", URL: "https://music.163.com", Err: os.ErrDeadlineExceeded}), true},
+		{"rate limited", &APIError{Code: 405, StatusCode: 200}, true},
+		{"http 429", &APIError{StatusCode: http.StatusTooManyRequests}, true},
+		{"http 502", &APIError{StatusCode: http.StatusBadGateway}, true},
+		{"invalid proxy", transport(proxyErr), false},
+		{"blocked", transport(errors.New("Request Blocked:https://music.163.com/api/v3/song/detail")), false},
+		{"certificate", transport(&url.Error{Op: "Post", URL: "https://music.163.com", Err: x509.UnknownAuthorityError{}}), false},
+		{"cassette miss", transport(ErrCassetteMiss), false},
+		{"canceled", transport(context.Canceled), false},
+		{"not logged in", &APIError{Code: 301, StatusCode: 200}, false},
+	}
+	for _, c := range cases {
+		if got := Retryable(c.err); got != c.want {
+			t.Errorf("%s: Retryable(%v) = %v, want %v", c.name, c.err, got, c.want)
+		}
+	}
+}
+
+func TestClient_RetryConfigError(t *testing.T) {
+	var attempts int32
+	client := &Client{
+		Proxy: "http://[::1",
+		Retry: &RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond},
+		Interceptors: []Interceptor{func(ctx context.Context, call *Call, next Invoker) (*Reply, error) {
+			atomic.AddInt32(&attempts, 1)
+			return next(ctx, call)
+		}},
+	}
+	_, _, _, err := client.CreateRequestContext(context.Background(), "POST", "https://music.163.com/api/v3/song/detail", map[string]string{}, &Options{Crypto: "weapi"})
+	if !errors.Is(err, ErrTransport) || attempts != 1 {
+		t.Fatalf("attempts %d, err %v", attempts, err)
+	}
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	p := &RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+	for n, want := range map[int]time.Duration{1: 100 * time.Millisecond, 2: 200 * time.Millisecond, 3: 400 * time.Millisecond, 10: time.Second} {
+		for i := 0; i < 20; i++ {
+			if d := p.backoff(n); d < want/2 || d > want {
+				t.Fatalf("backoff(%d) = %s, want within [%s, %s]", n, d, want/2, want)
+			}
+		}
+	}
+}
+
+func TestIsWriteAPI(t *testing.T) {
+	cases := map[string]bool{
+		"https://music.163.com/api/playlist/manipulate/tracks":      true,
+		"https://music.163.com/weapi/resource/comments/add":         true,
+		"https://music.163.com/weapi/v1/comment/like":               true,
+		"https://music.163.com/weapi/user/follow/123":               true,
+		"https://music.163.com/weapi/album/sub":                     true,
+		"https://music.163.com/weapi/playlist/subscribe":            true,
+		"https://clientlogusf.music.163.com/weapi/feedback/weblog":  true,
+		"https://music.163.com/weapi/v1/resource/comments/R_SO_4_1": false,
+		"https://music.163.com/weapi/v3/song/detail":                false,
+		"https://music.163.com/weapi/album/sublist":                 false,
+		"/api/song/like/get":                                        false,
+	}
+	for url, want := range cases {
+		if got := IsWriteAPI(url); got != want {
+			t.Errorf("IsWriteAPI(%q) = %v, want %v", url, got, want)
+		}
+	}
+}
@@ -0,0 +1,352 @@
+package util
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/buger/jsonparser"
+	"golang.org/x/crypto/pbkdf2"
+)
+
+// ErrWrongPassphrase 会话文件的密码错误或文件已损坏
+var ErrWrongPassphrase = errors.New("netease: 会话密码错误或文件已损坏")
+
+// sessionHosts 导出会话时读取 Cookie 的域名
+var sessionHosts = []string{HostMusic, HostInterface, HostInterface3, HostClientLogUsf}
+
+// sessionCookieLifetime 导入的 Cookie 的有效期，CookieJar 无法读取 Cookie 原本的过期时间，是否有效以服务端为准
+const sessionCookieLifetime = 365 * 24 * time.Hour
+
+// Session 登录会话的快照，包括网易云音乐各域名下的 Cookie、设备标识、模拟的客户端以及登录的账号
+//
+// Session 可以直接按 JSON 序列化，其中包含 MUSIC_U 等登录凭证，保存到磁盘时建议使用 Save 加密
+type Session struct {
+	Cookies []SessionCookie `json:"cookies"`
+	Device  *DeviceIdentity `json:"device,omitempty"`
+	Profile *ClientProfile  `json:"profile,omitempty"`
+	// UserID 登录的用户ID，未能从响应中得知时为0
+	UserID int64 `json:"userId,omitempty"`
+	// LoginAt 最近一次登录或刷新登录（服务端下发 MUSIC_U）的时间
+	LoginAt time.Time `json:"loginAt,omitempty"`
+	// ExpiresAt MUSIC_U 的过期时间，未知时为零值
+	ExpiresAt time.Time `json:"expiresAt,omitempty"`
+	// GuestExpiresAt 游客凭证 MUSIC_A 的过期时间，未知时为零值
+	GuestExpiresAt time.Time `json:"guestExpiresAt,omitempty"`
+}
+
+// SessionCookie 会话中的 Cookie
+type SessionCookie struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+	// Host Cookie 所属的域名，HostOnly 为 false 时对其子域名同样有效
+	Host     string `json:"host"`
+	HostOnly bool   `json:"hostOnly,omitempty"`
+}
+
+// ExportSession 导出默认 Client 的会话
+func ExportSession() *Session {
+	return defaultClient.ExportSession()
+}
+
+// ImportSession 将会话导入默认 Client 及其 CookieJar
+func ImportSession(s *Session) {
+	defaultClient.ImportSession(s)
+}
+
+// ExportSession 导出 Client 的会话
+//
+// music.163.com 下的 Cookie 按对子域名有效导出，其他域名只导出与之不同的 Cookie
+func (c *Client) ExportSession() *Session {
+	c = c.orDefault()
+	jar := c.CookieJar()
+	s := &Session{Device: c.Device()}
+
+	seen := make(map[string]string)
+	for i, host := range sessionHosts {
+		u, _ := url.Parse("https://" + host + "/")
+		for _, cookie := range jar.Cookies(u) {
+			if i == 0 {
+				seen[cookie.Name] = cookie.Value
+			} else if v, ok := seen[cookie.Name]; ok && v == cookie.Value {
+				continue
+			}
+			s.Cookies = append(s.Cookies, SessionCookie{Name: cookie.Name, Value: cookie.Value, Host: host, HostOnly: i > 0})
+		}
+	}
+
+	c.mu.Lock()
+	s.Profile = c.Profile
+	s.UserID, s.LoginAt, s.ExpiresAt, s.GuestExpiresAt = c.userID, c.loginAt, c.loginExpires, c.guestExpires
+	c.mu.Unlock()
+	return s
+}
+
+// ImportSession 将会话导入 Client 及其 CookieJar，会话中的设备标识与客户端会替换 Client 当前的设置
+//
+// 导入前会像 ClearSession 一样清除 Client 当前的会话，不会残留之前账号的 Cookie
+func (c *Client) ImportSession(s *Session) {
+	c = c.orDefault()
+	jar := c.CookieJar()
+	c.clearSessionCookies(jar)
+	expires := time.Now().Add(sessionCookieLifetime)
+	for _, sc := range s.Cookies {
+		u, err := url.Parse("https://" + sc.Host + "/")
+		if err != nil {
+			continue
+		}
+		cookie := &http.Cookie{Name: sc.Name, Value: sc.Value, Path: "/", Expires: expires}
+		if !sc.HostOnly {
+			cookie.Domain = sc.Host
+		}
+		jar.SetCookies(u, []*http.Cookie{cookie})
+	}
+	if s.Device != nil {
+		c.SetDevice(s.Device)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if s.Profile != nil {
+		c.Profile = s.Profile
+	}
+	c.userID, c.loginAt, c.loginExpires, c.guestExpires = s.UserID, s.LoginAt, s.ExpiresAt, s.GuestExpiresAt
+}
+
+// ClearSession 清除 Client 的登录状态，CookieJar 中网易云音乐各域名下的 Cookie 都会被设为过期，设备标识保持不变
+func (c *Client) ClearSession() {
+	c = c.orDefault()
+	c.clearSessionCookies(c.CookieJar())
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.userID, c.loginAt, c.loginExpires, c.guestExpires = 0, time.Time{}, time.Time{}, time.Time{}
+}
+
+// clearSessionCookies 将网易云音乐各域名下的 Cookie 设为过期，之后重新写入设备标识
+func (c *Client) clearSessionCookies(jar http.CookieJar) {
+	for _, host := range sessionHosts {
+		u, _ := url.Parse("https://" + host + "/")
+		var expired []*http.Cookie
+		for _, cookie := range jar.Cookies(u) {
+			expired = append(expired, &http.Cookie{Name: cookie.Name, Path: "/", Domain: host, MaxAge: -1})
+		}
+		jar.SetCookies(u, expired)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	saveDevice(jar, c.device)
+}
+
+// recordAccount 从响应下发的 Cookie 与响应体中记录登录时间、MUSIC_U 与 MUSIC_A 的过期时间以及用户ID，供 ExportSession 使用
+func (c *Client) recordAccount(setCookies []*http.Cookie, body []byte) {
+	var musicU, musicA *http.Cookie
+	for _, cookie := range setCookies {
+		switch cookie.Name {
+		case "MUSIC_U":
+			musicU = cookie
+		case "MUSIC_A":
+			musicA = cookie
+		}
+	}
+	userID, _ := jsonparser.GetInt(body, "account", "id")
+	if musicU == nil && musicA == nil && userID <= 0 {
+		return
+	}
+	now := time.Now()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if musicA != nil {
+		c.guestExpires = cookieExpires(musicA, now)
+	}
+	if musicU != nil {
+		if musicU.Value == "" || musicU.MaxAge < 0 {
+			// 退出登录
+			c.userID, c.loginAt, c.loginExpires = 0, time.Time{}, time.Time{}
+			return
+		}
+		c.loginAt, c.loginExpires = now, cookieExpires(musicU, now)
+	}
+	if userID > 0 {
+		c.userID = userID
+	}
+}
+
+// cookieExpires 返回服务端下发的 Cookie 的过期时间，删除 Cookie 时返回零值
+func cookieExpires(cookie *http.Cookie, now time.Time) time.Time {
+	switch {
+	case cookie.Value == "" || cookie.MaxAge < 0:
+		return time.Time{}
+	case cookie.MaxAge > 0:
+		return now.Add(time.Duration(cookie.MaxAge) * time.Second)
+	}
+	return cookie.Expires
+}
+
+// LoginExpires 返回服务端下发的 MUSIC_U 的过期时间，未知时返回零值
+func (c *Client) LoginExpires() time.Time {
+	c = c.orDefault()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.loginExpires
+}
+
+// GuestExpires 返回服务端下发的游客凭证 MUSIC_A 的过期时间，未知时返回零值
+func (c *Client) GuestExpires() time.Time {
+	c = c.orDefault()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.guestExpires
+}
+
+// sessionFile 加密后的会话文件
+type sessionFile struct {
+	Version    int    `json:"version"`
+	KDF        string `json:"kdf"`
+	Iterations int    `json:"iterations"`
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+	Data       []byte `json:"data"`
+}
+
+// sessionKDFIterations 由密码派生密钥时 PBKDF2-HMAC-SHA256 的迭代次数，
+// 解密时只接受 [sessionKDFIterations, sessionKDFMaxIterations] 之间的迭代次数，
+// 避免被篡改的文件降低迭代次数或使解密长时间无法返回
+const (
+	sessionKDFIterations    = 600000
+	sessionKDFMaxIterations = 10 * sessionKDFIterations
+)
+
+// Encrypt 使用 passphrase 加密会话，见 EncryptJSON
+func (s *Session) Encrypt(passphrase string) ([]byte, error) {
+	return EncryptJSON(s, passphrase)
+}
+
+// DecryptSession 使用 passphrase 解密 Session.Encrypt 加密的会话，密码错误时返回 ErrWrongPassphrase
+func DecryptSession(data []byte, passphrase string) (*Session, error) {
+	var s Session
+	if err := DecryptJSON(data, passphrase, &s); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// Save 使用 passphrase 加密会话并写入 path，见 SaveEncrypted
+func (s *Session) Save(path, passphrase string) error {
+	return SaveEncrypted(path, s, passphrase)
+}
+
+// LoadSession 读取并解密 Session.Save 保存的会话
+func LoadSession(path, passphrase string) (*Session, error) {
+	var s Session
+	if err := LoadEncrypted(path, passphrase, &s); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// EncryptJSON 将 v 序列化为 JSON 后使用 passphrase 加密，密钥由 PBKDF2-HMAC-SHA256 派生，内容使用 AES-256-GCM 加密
+func EncryptJSON(v interface{}, passphrase string) ([]byte, error) {
+	if passphrase == "" {
+		return nil, errors.New("netease: 会话密码不能为空")
+	}
+	plain, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("marshal session: %w", err)
+	}
+	f := sessionFile{Version: 1, KDF: "pbkdf2-sha256", Iterations: sessionKDFIterations, Salt: make([]byte, 16)}
+	if _, err := rand.Read(f.Salt); err != nil {
+		return nil, fmt.Errorf("generate salt: %w", err)
+	}
+	aead, err := sessionCipher(passphrase, f.Salt, f.Iterations)
+	if err != nil {
+		return nil, err
+	}
+	f.Nonce = make([]byte, aead.NonceSize())
+	if _, err := rand.Read(f.Nonce); err != nil {
+		return nil, fmt.Errorf("generate nonce: %w", err)
+	}
+	f.Data = aead.Seal(nil, f.Nonce, plain, nil)
+	return json.Marshal(f)
+}
+
+// DecryptJSON 使用 passphrase 解密 EncryptJSON 加密的数据并反序列化到 v，密码错误时返回 ErrWrongPassphrase
+func DecryptJSON(data []byte, passphrase string, v interface{}) error {
+	var f sessionFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return fmt.Errorf("%w: %v", ErrWrongPassphrase, err)
+	}
+	if f.Version != 1 || f.KDF != "pbkdf2-sha256" {
+		return fmt.Errorf("netease: 不支持的会话文件版本 %d (%s)", f.Version, f.KDF)
+	}
+	if f.Iterations < sessionKDFIterations || f.Iterations > sessionKDFMaxIterations {
+		return fmt.Errorf("netease: 会话文件的迭代次数 %d 不在 %d 到 %d 之间", f.Iterations, sessionKDFIterations, sessionKDFMaxIterations)
+	}
+	aead, err := sessionCipher(passphrase, f.Salt, f.Iterations)
+	if err != nil {
+		return err
+	}
+	if len(f.Nonce) != aead.NonceSize() {
+		return ErrWrongPassphrase
+	}
+	plain, err := aead.Open(nil, f.Nonce, f.Data, nil)
+	if err != nil {
+		return ErrWrongPassphrase
+	}
+	if err := json.Unmarshal(plain, v); err != nil {
+		return fmt.Errorf("unmarshal session: %w", err)
+	}
+	return nil
+}
+
+// SaveEncrypted 使用 EncryptJSON 加密 v 并写入 path，文件权限为 0600
+func SaveEncrypted(path string, v interface{}, passphrase string) error {
+	data, err := EncryptJSON(v, passphrase)
+	if err != nil {
+		return err
+	}
+	// 先写入临时文件再重命名，避免写入中断时损坏原有的文件
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("save session: %w", err)
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return fmt.Errorf("save session: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return fmt.Errorf("save session: %w", err)
+	}
+	if err := os.Rename(f.Name(), path); err != nil {
+		os.Remove(f.Name())
+		return fmt.Errorf("save session: %w", err)
+	}
+	return nil
+}
+
+// LoadEncrypted 读取 SaveEncrypted 写入的文件并解密到 v
+func LoadEncrypted(path, passphrase string, v interface{}) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("load session: %w", err)
+	}
+	return DecryptJSON(data, passphrase, v)
+}
+
+func sessionCipher(passphrase string, salt []byte, iterations int) (cipher.AEAD, error) {
+	key := pbkdf2.Key([]byte(passphrase), salt, iterations, 32, sha256.New)
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,220 @@
+package util
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDecryptJSON_Iterations(t *testing.T) {
+	data, err := EncryptJSON(map[string]string{"a": "b"}, "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var f sessionFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		t.Fatal(err)
+	}
+	// 被篡改的迭代次数在派生密钥之前就被拒绝
+	for _, n := range []int{0, 1, sessionKDFIterations - 1, sessionKDFMaxIterations + 1, 1 << 40} {
+		f.Iterations = n
+		tampered, _ := json.Marshal(f)
+		start := time.Now()
+		var v map[string]string
+		if err := DecryptJSON(tampered, "secret", &v); err == nil || errors.Is(err, ErrWrongPassphrase) {
+			t.Errorf("iterations %d: err = %v", n, err)
+		}
+		if time.Since(start) > time.Second {
+			t.Errorf("iterations %d: took %s", n, time.Since(start))
+		}
+	}
+}
+
+// loginClient 返回一个已登录（通过假响应）的 Client
+func loginClient(t *testing.T) *Client {
+	t.Helper()
+	client := &Client{Profile: AndroidProfile(), Transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		header := http.Header{}
+		header.Add("Set-Cookie", "MUSIC_U=token-123; Path=/; Domain=.music.163.com")
+		header.Add("Set-Cookie", "__csrf=csrf-123; Path=/; Domain=.music.163.com")
+		return &http.Response{StatusCode: 200, Header: header, Body: newBody(`{"code":200,"account":{"id":42}}`), Request: r}, nil
+	})}
+	if _, _, _, err := client.CreateRequestContext(context.Background(), "POST", "https://music.163.com/weapi/login/cellphone", map[string]string{}, &Options{Crypto: "weapi"}); err != nil {
+		t.Fatal(err)
+	}
+	// interface.music.163.com 下独有的 Cookie
+	u, _ := url.Parse("https://interface.music.163.com/")
+	client.CookieJar().SetCookies(u, []*http.Cookie{{Name: "MUSIC_R_T", Value: "refresh", Path: "/"}})
+	return client
+}
+
+func TestClient_ExportImportSession(t *testing.T) {
+	client := loginClient(t)
+	s := client.ExportSession()
+	if s.UserID != 42 || s.LoginAt.IsZero() {
+		t.Fatalf("account not recorded: %d, %v", s.UserID, s.LoginAt)
+	}
+	if s.Profile == nil || s.Profile.OS != "android" {
+		t.Fatalf("profile not exported: %+v", s.Profile)
+	}
+
+	restored := NewClient(nil)
+	restored.ImportSession(s)
+	if got := GetCsrfToken(restored.CookieJar()); got != "csrf-123" {
+		t.Fatalf("csrf token = %q", got)
+	}
+	if got, want := restored.Device(), client.Device(); *got != *want {
+		t.Fatalf("device = %+v, want %+v", got, want)
+	}
+	if restored.Profile == nil || restored.Profile.OS != "android" {
+		t.Fatalf("profile = %+v", restored.Profile)
+	}
+	for host, want := range map[string]string{"music.163.com": "", "interface.music.163.com": "refresh"} {
+		u, _ := url.Parse("https://" + host + "/")
+		cookies := restored.CookieJar().Cookies(u)
+		if got := CookieValueByName(cookies, "MUSIC_R_T", ""); got != want {
+			t.Errorf("%s MUSIC_R_T = %q, want %q", host, got, want)
+		}
+		if got := CookieValueByName(cookies, "MUSIC_U", ""); got != "token-123" {
+			t.Errorf("%s MUSIC_U = %q", host, got)
+		}
+	}
+	if again := restored.ExportSession(); again.UserID != 42 || !again.LoginAt.Equal(s.LoginAt) {
+		t.Fatalf("account not imported: %+v", again)
+	}
+}
+
+func TestClient_ImportSessionReplaces(t *testing.T) {
+	s := &Session{
+		Cookies: []SessionCookie{{Name: "MUSIC_U", Value: "token-456", Host: HostMusic}},
+		UserID:  43,
+	}
+	client := loginClient(t)
+	client.ImportSession(s)
+
+	// 之前账号的 Cookie 不会残留
+	for host, want := range map[string]string{"music.163.com": "token-456", "interface.music.163.com": "token-456"} {
+		u, _ := url.Parse("https://" + host + "/")
+		cookies := client.CookieJar().Cookies(u)
+		if got := CookieValueByName(cookies, "MUSIC_U", ""); got != want {
+			t.Errorf("%s MUSIC_U = %q, want %q", host, got, want)
+		}
+		for _, name := range []string{"__csrf", "MUSIC_R_T"} {
+			if got := CookieValueByName(cookies, name, ""); got != "" {
+				t.Errorf("%s %s left: %q", host, name, got)
+			}
+		}
+	}
+	if client.Device().DeviceId == "" {
+		t.Error("device cookies cleared")
+	}
+	if got := client.ExportSession(); got.UserID != 43 || got.Profile == nil || got.Profile.OS != "android" {
+		t.Errorf("session = %+v", got)
+	}
+}
+
+func TestSession_SaveLoad(t *testing.T) {
+	s := loginClient(t).ExportSession()
+	path := filepath.Join(t.TempDir(), "session")
+	if err := s.Save(path, "secret"); err != nil {
+		t.Fatal(err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0o600 {
+		t.Errorf("mode = %v", info.Mode().Perm())
+	}
+	data, _ := os.ReadFile(path)
+	if strings.Contains(string(data), "token-123") {
+		t.Fatal("session saved in plain text")
+	}
+
+	if _, err := LoadSession(path, "wrong"); !errors.Is(err, ErrWrongPassphrase) {
+		t.Fatalf("wrong passphrase: %v", err)
+	}
+	loaded, err := LoadSession(path, "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	jar, _ := cookiejar.New(nil)
+	client := NewClient(jar)
+	client.ImportSession(loaded)
+	if got := GetCsrfToken(jar); got != "csrf-123" {
+		t.Fatalf("csrf token = %q", got)
+	}
+	if _, err := s.Encrypt(""); err == nil {
+		t.Fatal("empty passphrase accepted")
+	}
+}
+
+func TestClient_ClearSession(t *testing.T) {
+	client := loginClient(t)
+	device := client.Device()
+	client.ClearSession()
+
+	if got := GetCsrfToken(client.CookieJar()); got != "" {
+		t.Fatalf("csrf token = %q", got)
+	}
+	s := client.ExportSession()
+	for _, c := range s.Cookies {
+		if c.Name == "MUSIC_U" || c.Name == "MUSIC_R_T" {
+			t.Errorf("%s left on %s", c.Name, c.Host)
+		}
+	}
+	if s.UserID != 0 || !s.LoginAt.IsZero() {
+		t.Errorf("account not cleared: %+v", s)
+	}
+	if got := NewClient(client.CookieJar()).Device(); *got != *device {
+		t.Errorf("device changed: %+v, want %+v", got, device)
+	}
+}
+
+func TestClient_RequestCookiesNotStored(t *testing.T) {
+	client := loginClient(t)
+	var sent []*http.Cookie
+	client.Transport = roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		sent = r.Cookies()
+		return &http.Response{StatusCode: 200, Header: http.Header{}, Body: newBody(`{"code":200}`), Request: r}, nil
+	})
+	request := func(url string, options *Options) {
+		t.Helper()
+		if _, _, _, err := client.CreateRequestContext(context.Background(), "POST", url, map[string]string{}, options); err != nil {
+			t.Fatal(err)
+		}
+	}
+	request("https://music.163.com/weapi/v3/song/detail", &Options{Crypto: "weapi"})
+	request("https://interface.music.163.com/eapi/v3/song/detail", &Options{Crypto: "eapi", Url: "/api/v3/song/detail"})
+	if got := CookieValueByName(sent, "MUSIC_U", ""); got != "token-123" {
+		t.Fatalf("MUSIC_U not sent: %q", got)
+	}
+
+	// 请求附带的 Cookie 不会按接口路径写入 CookieJar
+	for _, host := range []string{"music.163.com", "interface.music.163.com"} {
+		root, _ := url.Parse("https://" + host + "/")
+		for _, path := range []string{"/weapi/v3/song/detail", "/eapi/v3/song/detail"} {
+			u, _ := url.Parse("https://" + host + path)
+			if got, want := len(client.CookieJar().Cookies(u)), len(client.CookieJar().Cookies(root)); got != want {
+				t.Errorf("%s%s has %d cookies, / has %d", host, path, got, want)
+			}
+		}
+	}
+
+	client.ClearSession()
+	request("https://music.163.com/weapi/v3/song/detail", &Options{Crypto: "weapi"})
+	request("https://interface.music.163.com/eapi/v3/song/detail", &Options{Crypto: "eapi", Url: "/api/v3/song/detail"})
+	for _, name := range []string{"MUSIC_U", "__csrf"} {
+		if got := CookieValueByName(sent, name, ""); got != "" {
+			t.Errorf("%s sent after ClearSession: %q", name, got)
+		}
+	}
+}
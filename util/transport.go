@@ -0,0 +1,115 @@
+package util
+
+import (
+	"crypto/tls"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// 网易云音乐使用到的域名，可作为 Client.BaseURLs 的键
+const (
+	HostMusic        = "music.163.com"
+	HostInterface    = "interface.music.163.com"
+	HostInterface3   = "interface3.music.163.com"
+	HostClientLogUsf = "clientlogusf.music.163.com"
+)
+
+// sharedTransport 未设置 Client.Transport 与代理时所有 Client 共用的连接池
+var sharedTransport = NewTransport()
+
+// insecureTransport 设置了 Client.InsecureSkipVerify 且未使用代理时共用的连接池，不校验服务端证书
+var insecureTransport = sync.OnceValue(func() http.RoundTripper {
+	t := NewTransport()
+	t.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+	return t
+})
+
+// NewTransport 返回适合请求网易云音乐接口的 http.Transport
+//
+// 与 http.DefaultTransport 相比提高了每个域名保留的空闲连接数，使并发请求（如 PlaylistTrackAllService）
+// 能够复用连接，并在可能时使用 HTTP/2。可以在此基础上修改后设置为 Client.Transport
+func NewTransport() *http.Transport {
+	return &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          100,
+		MaxIdleConnsPerHost:   32,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: time.Second,
+	}
+}
+
+// roundTripper 返回 Client 发送请求使用的 RoundTripper
+//
+// 优先使用 Client.Transport，其次是代理 proxy 对应的 Transport，都没有时使用共享的连接池。
+// 设置了 Client.BaseURLs 时会在最外层按域名改写请求地址
+func (c *Client) roundTripper(proxy string) (http.RoundTripper, error) {
+	rt := c.Transport
+	if rt == nil && proxy != "" {
+		var err error
+		if rt, err = proxyTransport(proxy, c.NoProxy, c.InsecureSkipVerify); err != nil {
+			return nil, err
+		}
+	}
+	if rt == nil && c.InsecureSkipVerify {
+		rt = insecureTransport()
+	}
+	if rt == nil {
+		rt = sharedTransport
+	}
+	if len(c.BaseURLs) == 0 {
+		return rt, nil
+	}
+	targets := make(map[string]*url.URL, len(c.BaseURLs))
+	for host, base := range c.BaseURLs {
+		if u, err := url.Parse(base); err == nil {
+			targets[host] = u
+		}
+	}
+	return &rewriteTransport{base: rt, targets: targets}, nil
+}
+
+// httpClient 返回使用 Client 的 CookieJar 与 RoundTripper 的 http.Client
+//
+// http.Client 本身只是很轻的包装，每次请求新建也不影响连接复用，连接池由 RoundTripper 持有
+func (c *Client) httpClient(proxy string) (*http.Client, error) {
+	rt, err := c.roundTripper(proxy)
+	if err != nil {
+		return nil, err
+	}
+	return &http.Client{Jar: c.CookieJar(), Transport: rt}, nil
+}
+
+// rewriteTransport 将发往网易云音乐域名的请求改写到其他地址
+//
+// 改写发生在 RoundTrip 阶段，CookieJar 中的 Cookie 依旧以原域名保存，
+// 因此改写对 GetCsrfToken 等读取 Cookie 的逻辑是透明的
+type rewriteTransport struct {
+	base    http.RoundTripper
+	targets map[string]*url.URL
+}
+
+func (t *rewriteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	target, ok := t.targets[req.URL.Hostname()]
+	if !ok {
+		return t.base.RoundTrip(req)
+	}
+	r := req.Clone(req.Context())
+	r.URL.Scheme = target.Scheme
+	r.URL.Host = target.Host
+	r.URL.Path = strings.TrimSuffix(target.Path, "/") + req.URL.Path
+	if req.URL.RawPath != "" {
+		r.URL.RawPath = strings.TrimSuffix(target.Path, "/") + req.URL.RawPath
+	}
+	r.Host = target.Host
+	return t.base.RoundTrip(r)
+}
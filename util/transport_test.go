@@ -0,0 +1,201 @@
+package util
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httptrace"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
+func TestClient_BaseURLs(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.Host+r.URL.Path)
+		http.SetCookie(w, &http.Cookie{Name: "__csrf", Value: "token", Path: "/"})
+		_, _ = w.Write([]byte(`{"code":200}`))
+	}))
+	defer server.Close()
+
+	serverURL, _ := url.Parse(server.URL)
+	client := &Client{BaseURLs: map[string]string{
+		HostMusic:        server.URL,
+		HostClientLogUsf: server.URL + "/log",
+	}}
+
+	code, _, _, err := client.CreateRequestContext(context.Background(), "POST", "https://music.163.com/api/nuser/account/get", map[string]string{}, &Options{Crypto: "weapi"})
+	if err != nil || code != 200 {
+		t.Fatalf("unexpected result: code %f, err %v", code, err)
+	}
+	if _, _, err := client.CallWeapi("https://clientlogusf.music.163.com/weapi/feedback/weblog", map[string]interface{}{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []string{serverURL.Host + "/weapi/nuser/account/get", serverURL.Host + "/log/weapi/feedback/weblog"}
+	if len(paths) != len(want) || paths[0] != want[0] || paths[1] != want[1] {
+		t.Fatalf("unexpected requests: %v", paths)
+	}
+	// Cookie 仍然保存在网易云音乐的域名下
+	if token := GetCsrfToken(client.CookieJar()); token != "token" {
+		t.Fatalf("csrf token not stored for music.163.com: %q", token)
+	}
+}
+
+func TestClient_Transport(t *testing.T) {
+	var calls int32
+	client := &Client{Transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		atomic.AddInt32(&calls, 1)
+		rec := httptest.NewRecorder()
+		_, _ = rec.WriteString(`{"code":200,"data":[]}`)
+		return rec.Result(), nil
+	})}
+
+	code, body, _, err := client.CreateRequestContext(context.Background(), "POST", "https://music.163.com/api/search/hot", map[string]string{}, &Options{Crypto: "weapi"})
+	if err != nil || code != 200 || string(body) != `{"code":200,"data":[]}` {
+		t.Fatalf("unexpected result: code %f, body %s, err %v", code, body, err)
+	}
+	if atomic.LoadInt32(&calls) != 1 {
+		t.Fatalf("transport not used, calls: %d", calls)
+	}
+}
+
+// countingServer 启动一个统计新建连接数的本地服务
+func countingServer(tls bool) (*httptest.Server, *int32) {
+	var conns int32
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"code":200,"songs":[]}`))
+	}))
+	server.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			atomic.AddInt32(&conns, 1)
+		}
+	}
+	if tls {
+		server.EnableHTTP2 = true
+		server.StartTLS()
+	} else {
+		server.Start()
+	}
+	return server, &conns
+}
+
+func TestClient_ConnectionReuse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"code":200,"songs":[]}`))
+	}))
+	defer server.Close()
+
+	// 使用独立的连接池，不受其他测试共用的 sharedTransport 影响
+	transport := NewTransport()
+	defer transport.CloseIdleConnections()
+	client := &Client{Transport: transport, BaseURLs: map[string]string{HostMusic: server.URL}}
+	var dialed, reused int32
+	ctx := httptrace.WithClientTrace(context.Background(), &httptrace.ClientTrace{
+		GotConn: func(info httptrace.GotConnInfo) {
+			if info.Reused {
+				atomic.AddInt32(&reused, 1)
+			} else {
+				atomic.AddInt32(&dialed, 1)
+			}
+		},
+	})
+	request := func() {
+		code, _, _, err := client.CreateRequestContext(ctx, "POST", "https://music.163.com/weapi/v3/song/detail", map[string]string{}, &Options{Crypto: "weapi"})
+		if err != nil || code != 200 {
+			t.Errorf("code %f, err %v", code, err)
+		}
+	}
+
+	for i := 0; i < 10; i++ {
+		request()
+	}
+	if d, r := atomic.LoadInt32(&dialed), atomic.LoadInt32(&reused); d != 1 || r != 9 {
+		t.Fatalf("sequential requests: %d new connections, %d reused", d, r)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				request()
+			}
+		}()
+	}
+	wg.Wait()
+	// 并发时可能为等待中的请求多建立几个连接，但大部分请求应复用已有连接
+	if r := atomic.LoadInt32(&reused) - 9; r < 30 {
+		t.Fatalf("concurrent requests reused connections %d/40 times", r)
+	}
+}
+
+func TestClient_SharedTransport(t *testing.T) {
+	for _, client := range []*Client{{}, {Profile: AndroidProfile()}} {
+		if rt, err := client.roundTripper(""); err != nil || rt != http.RoundTripper(sharedTransport) {
+			t.Fatalf("roundTripper = %v, %v; want sharedTransport", rt, err)
+		}
+	}
+}
+
+func TestClient_InvalidProxy(t *testing.T) {
+	client := &Client{Proxy: "://invalid"}
+	_, _, _, err := client.CreateRequestContext(context.Background(), "POST", "https://music.163.com/weapi/v3/song/detail", map[string]string{}, &Options{Crypto: "weapi"})
+	if !errors.Is(err, ErrTransport) {
+		t.Fatalf("expected transport error, got %v", err)
+	}
+	if _, err := client.NewRequest("https://music.163.com/api/search/hot").SendGet(); err == nil {
+		t.Fatal("expected error for invalid proxy")
+	}
+}
+
+// benchmarkCreateRequest 对本地 HTTPS 服务并发请求，newTransport 为 true 时模拟每次请求新建连接池
+func benchmarkCreateRequest(b *testing.B, newTransport bool) {
+	server, conns := countingServer(true)
+	defer server.Close()
+
+	pool := x509.NewCertPool()
+	pool.AddCert(server.Certificate())
+	transport := func() *http.Transport {
+		t := NewTransport()
+		t.TLSClientConfig = &tls.Config{RootCAs: pool}
+		return t
+	}
+	client := &Client{BaseURLs: map[string]string{HostMusic: server.URL}, Transport: transport()}
+	if newTransport {
+		client.Transport = roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			t := transport()
+			defer t.CloseIdleConnections()
+			return t.RoundTrip(r)
+		})
+	}
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if code, _, _, err := client.CreateRequestContext(context.Background(), "POST", "https://music.163.com/weapi/v3/song/detail", map[string]string{"ids": "[1]"}, &Options{Crypto: "weapi"}); err != nil || code != 200 {
+				b.Errorf("code %f, err %v", code, err)
+			}
+		}
+	})
+	b.ReportMetric(float64(atomic.LoadInt32(conns))/float64(b.N), "conns/op")
+}
+
+func BenchmarkCreateRequest_SharedTransport(b *testing.B) {
+	benchmarkCreateRequest(b, false)
+}
+
+func BenchmarkCreateRequest_TransportPerRequest(b *testing.B) {
+	benchmarkCreateRequest(b, true)
+}